@@ -0,0 +1,252 @@
+package mexcconditional
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/market"
+	"github.com/shopspring/decimal"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OrderService is a part of market service which places orders
+type OrderService interface {
+	CreateOrder(ctx context.Context, req *mexchttpmarket.CreateOrderRequest) (*mexchttpmarket.CreateOrderResponse, error)
+}
+
+// Engine watches prices and submits child orders of pending triggers.
+// Sell triggers are evaluated against bid price, buy triggers against ask price.
+type Engine struct {
+	ctx      context.Context
+	orders   OrderService
+	store    Store
+	onFire   func(Trigger)
+	onError  func(error)
+	mtx      *sync.Mutex
+	triggers map[string]*Trigger
+	dirty    map[string]struct{} // pending triggers with updated extreme, saved after mtx is released
+	// saveMtx orders store writes, it's taken before mtx
+	saveMtx *sync.Mutex
+}
+
+// NewEngine restores pending triggers from store. ctx is used for order placement and store calls.
+// onFire is called after child order is placed or failed, onError reports store errors; both are optional.
+func NewEngine(ctx context.Context, orders OrderService, store Store, onFire func(Trigger), onError func(error)) (*Engine, error) {
+	e := &Engine{
+		ctx:      ctx,
+		orders:   orders,
+		store:    store,
+		onFire:   onFire,
+		onError:  onError,
+		mtx:      new(sync.Mutex),
+		triggers: make(map[string]*Trigger),
+		dirty:    make(map[string]struct{}),
+		saveMtx:  new(sync.Mutex),
+	}
+
+	triggers, err := store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load triggers: %w", err)
+	}
+	for _, t := range triggers {
+		if t.Status == TriggerStatusPending {
+			e.triggers[t.ID] = t
+		}
+	}
+
+	return e, nil
+}
+
+// Watch subscribes to deals of symbols and evaluates triggers on every trade
+func (e *Engine) Watch(ws *mexcwsmarket.Service, symbols []string, interval string) error {
+	return ws.DealsSubscribe(e.ctx, symbols, interval, e.HandleDeals)
+}
+
+// Add validates and persists trigger, ID is generated if empty
+func (e *Engine) Add(t *Trigger) error {
+	if err := t.validate(); err != nil {
+		return err
+	}
+
+	trigger := *t
+	if trigger.ID == "" {
+		trigger.ID = strings.ReplaceAll(uuid.NewString(), "-", "")
+	}
+	if trigger.Order.NewClientOrderId == nil {
+		// exchange rejects duplicates, so child order can't be placed twice
+		id := trigger.ID
+		trigger.Order.NewClientOrderId = &id
+	}
+	trigger.Status = TriggerStatusPending
+	trigger.CreatedAt = time.Now()
+
+	e.saveMtx.Lock()
+	defer e.saveMtx.Unlock()
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if _, ok := e.triggers[trigger.ID]; ok {
+		return fmt.Errorf("trigger %s already exists", trigger.ID)
+	}
+	if err := e.store.Save(e.ctx, &trigger); err != nil {
+		return fmt.Errorf("save trigger: %w", err)
+	}
+	e.triggers[trigger.ID] = &trigger
+	*t = trigger
+
+	return nil
+}
+
+// Cancel removes pending trigger
+func (e *Engine) Cancel(id string) error {
+	e.saveMtx.Lock()
+	defer e.saveMtx.Unlock()
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if _, ok := e.triggers[id]; !ok {
+		return fmt.Errorf("trigger %s not found", id)
+	}
+	if err := e.store.Delete(e.ctx, id); err != nil {
+		return fmt.Errorf("delete trigger: %w", err)
+	}
+	delete(e.triggers, id)
+	delete(e.dirty, id)
+
+	return nil
+}
+
+// Pending returns copies of pending triggers
+func (e *Engine) Pending() []Trigger {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	res := make([]Trigger, 0, len(e.triggers))
+	for _, t := range e.triggers {
+		res = append(res, *t)
+	}
+	return res
+}
+
+// HandleDeals matches mexcwsmarket.Service.DealsSubscribe callback
+func (e *Engine) HandleDeals(api *dto.PublicAggreDealsV3Api, symbol string) {
+	for _, deal := range api.Deals {
+		price, err := decimal.NewFromString(deal.Price)
+		if err != nil {
+			continue
+		}
+		e.OnPrice(symbol, price, price)
+	}
+}
+
+// HandleBookTicker matches mexcwsmarket.Service.BookTickerSubscribe callback
+func (e *Engine) HandleBookTicker(api *dto.PublicAggreBookTickerV3Api, symbol string) {
+	bid, err := decimal.NewFromString(api.BidPrice)
+	if err != nil {
+		return
+	}
+	ask, err := decimal.NewFromString(api.AskPrice)
+	if err != nil {
+		return
+	}
+	e.OnPrice(symbol, bid, ask)
+}
+
+// OnPrice evaluates pending triggers of symbol, triggers with updated extreme are saved without holding engine lock
+func (e *Engine) OnPrice(symbol string, bid, ask decimal.Decimal) {
+	var fired []Trigger
+	updated := false
+
+	e.mtx.Lock()
+	for id, t := range e.triggers {
+		if t.Order.Symbol != symbol {
+			continue
+		}
+
+		price := ask
+		if t.Order.Side == mexchttpmarket.SideSell {
+			price = bid
+		}
+
+		ok, changed := t.evaluate(price)
+		switch {
+		case ok:
+			fired = append(fired, *t)
+			delete(e.triggers, id)
+			delete(e.dirty, id)
+		case changed:
+			e.dirty[id] = struct{}{}
+			updated = true
+		}
+	}
+	e.mtx.Unlock()
+
+	for i := range fired {
+		go e.fire(fired[i])
+	}
+	if updated {
+		e.saveDirty()
+	}
+}
+
+// saveDirty saves copies of triggers which are still pending, so fired or cancelled trigger isn't written back
+func (e *Engine) saveDirty() {
+	e.saveMtx.Lock()
+	defer e.saveMtx.Unlock()
+
+	e.mtx.Lock()
+	triggers := make([]Trigger, 0, len(e.dirty))
+	for id := range e.dirty {
+		if t, ok := e.triggers[id]; ok {
+			triggers = append(triggers, *t)
+		}
+	}
+	clear(e.dirty)
+	e.mtx.Unlock()
+
+	for i := range triggers {
+		if err := e.store.Save(e.ctx, &triggers[i]); err != nil {
+			e.reportError(fmt.Errorf("save trigger %s: %w", triggers[i].ID, err))
+		}
+	}
+}
+
+func (e *Engine) fire(t Trigger) {
+	now := time.Now()
+	t.TriggeredAt = &now
+
+	resp, err := e.orders.CreateOrder(e.ctx, &t.Order)
+
+	e.saveMtx.Lock()
+	if err != nil {
+		t.Status = TriggerStatusFailed
+		t.Error = err.Error()
+
+		// failed trigger is kept for inspection, it isn't armed again
+		if sErr := e.store.Save(e.ctx, &t); sErr != nil {
+			e.reportError(fmt.Errorf("save trigger %s: %w", t.ID, sErr))
+		}
+	} else {
+		t.Status = TriggerStatusTriggered
+		t.OrderID = resp.OrderId
+
+		if sErr := e.store.Delete(e.ctx, t.ID); sErr != nil {
+			e.reportError(fmt.Errorf("delete trigger %s: %w", t.ID, sErr))
+		}
+	}
+	e.saveMtx.Unlock()
+
+	if e.onFire != nil {
+		e.onFire(t)
+	}
+}
+
+func (e *Engine) reportError(err error) {
+	if e.onError != nil {
+		e.onError(err)
+	}
+}
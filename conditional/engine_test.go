@@ -0,0 +1,137 @@
+package mexcconditional
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOrderService struct {
+	orders chan *mexchttpmarket.CreateOrderRequest
+}
+
+func (f *fakeOrderService) CreateOrder(_ context.Context,
+	req *mexchttpmarket.CreateOrderRequest) (*mexchttpmarket.CreateOrderResponse, error) {
+	f.orders <- req
+	return &mexchttpmarket.CreateOrderResponse{OrderId: "1"}, nil
+}
+
+func sellOrder() mexchttpmarket.CreateOrderRequest {
+	qty := "1"
+	return mexchttpmarket.CreateOrderRequest{
+		Symbol:   "BTCUSDT",
+		Side:     mexchttpmarket.SideSell,
+		Type:     mexchttpmarket.TypeMarket,
+		Quantity: &qty,
+	}
+}
+
+func TestEngine_TrailingStopSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "triggers.json")
+
+	store, err := NewFileStore(path)
+	require.NoError(t, err)
+
+	orders := &fakeOrderService{orders: make(chan *mexchttpmarket.CreateOrderRequest, 1)}
+	engine, err := NewEngine(ctx, orders, store, nil, nil)
+	require.NoError(t, err)
+
+	trigger := &Trigger{Kind: KindTrailingStop, TrailingDelta: decimal.RequireFromString("0.1"), Order: sellOrder()}
+	require.NoError(t, engine.Add(trigger))
+
+	for _, p := range []int64{100, 120, 110} {
+		engine.OnPrice("BTCUSDT", decimal.NewFromInt(p), decimal.NewFromInt(p))
+	}
+
+	// restart
+	store, err = NewFileStore(path)
+	require.NoError(t, err)
+	fired := make(chan Trigger, 1)
+	engine, err = NewEngine(ctx, orders, store, func(t Trigger) { fired <- t }, nil)
+	require.NoError(t, err)
+
+	pending := engine.Pending()
+	require.Len(t, pending, 1)
+	assert.True(t, decimal.NewFromInt(120).Equal(pending[0].Extreme))
+
+	engine.OnPrice("BTCUSDT", decimal.NewFromInt(108), decimal.NewFromInt(108))
+
+	select {
+	case req := <-orders.orders:
+		assert.Equal(t, trigger.ID, *req.NewClientOrderId)
+	case <-time.After(time.Second):
+		t.Fatal("child order wasn't placed")
+	}
+
+	result := <-fired
+	assert.Equal(t, TriggerStatusTriggered, result.Status)
+	assert.Empty(t, engine.Pending())
+
+	loaded, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+}
+
+// slowStore blocks saves until released
+type slowStore struct {
+	*MemoryStore
+	saving  chan struct{}
+	release chan struct{}
+}
+
+func (s *slowStore) Save(ctx context.Context, t *Trigger) error {
+	s.saving <- struct{}{}
+	<-s.release
+	return s.MemoryStore.Save(ctx, t)
+}
+
+func TestEngine_SavesExtremeWithoutLock(t *testing.T) {
+	ctx := context.Background()
+	store := &slowStore{MemoryStore: NewMemoryStore(), saving: make(chan struct{}, 1), release: make(chan struct{}, 1)}
+	engine, err := NewEngine(ctx, &fakeOrderService{}, store, nil, nil)
+	require.NoError(t, err)
+
+	store.release <- struct{}{}
+	trigger := &Trigger{Kind: KindTrailingStop, TrailingDelta: decimal.RequireFromString("0.1"), Order: sellOrder()}
+	require.NoError(t, engine.Add(trigger))
+	<-store.saving
+
+	done := make(chan struct{})
+	go func() {
+		engine.OnPrice("BTCUSDT", decimal.NewFromInt(100), decimal.NewFromInt(100))
+		close(done)
+	}()
+	<-store.saving
+
+	// engine isn't locked while extreme is saved
+	pending := engine.Pending()
+	require.Len(t, pending, 1)
+	assert.True(t, decimal.NewFromInt(100).Equal(pending[0].Extreme))
+
+	store.release <- struct{}{}
+	<-done
+	loaded, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, loaded, 1)
+	assert.True(t, decimal.NewFromInt(100).Equal(loaded[0].Extreme))
+}
+
+func TestTrigger_Evaluate(t *testing.T) {
+	stop := &Trigger{Kind: KindStopLoss, TriggerPrice: decimal.NewFromInt(90), Order: sellOrder()}
+	fired, _ := stop.evaluate(decimal.NewFromInt(95))
+	assert.False(t, fired)
+	fired, _ = stop.evaluate(decimal.NewFromInt(90))
+	assert.True(t, fired)
+
+	take := &Trigger{Kind: KindTakeProfit, TriggerPrice: decimal.NewFromInt(110), Order: sellOrder()}
+	take.Order.Side = mexchttpmarket.SideBuy
+	fired, _ = take.evaluate(decimal.NewFromInt(109))
+	assert.True(t, fired)
+}
@@ -0,0 +1,140 @@
+package mexcconditional
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Store persists pending triggers so they survive restarts
+type Store interface {
+	Save(ctx context.Context, t *Trigger) error
+	Delete(ctx context.Context, id string) error
+	Load(ctx context.Context) ([]*Trigger, error)
+}
+
+// MemoryStore keeps triggers in memory only
+type MemoryStore struct {
+	mtx      *sync.Mutex
+	triggers map[string]Trigger
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		mtx:      new(sync.Mutex),
+		triggers: make(map[string]Trigger),
+	}
+}
+
+func (s *MemoryStore) Save(_ context.Context, t *Trigger) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.triggers[t.ID] = *t
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.triggers, id)
+	return nil
+}
+
+func (s *MemoryStore) Load(_ context.Context) ([]*Trigger, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	res := make([]*Trigger, 0, len(s.triggers))
+	for id := range s.triggers {
+		t := s.triggers[id]
+		res = append(res, &t)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+	return res, nil
+}
+
+// FileStore keeps triggers in JSON file, the file is rewritten atomically on every change
+type FileStore struct {
+	*MemoryStore
+	path     string
+	flushMtx *sync.Mutex
+}
+
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{
+		MemoryStore: NewMemoryStore(),
+		path:        path,
+		flushMtx:    new(sync.Mutex),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var triggers []Trigger
+	if err := json.Unmarshal(data, &triggers); err != nil {
+		return nil, err
+	}
+	for i := range triggers {
+		s.triggers[triggers[i].ID] = triggers[i]
+	}
+
+	return s, nil
+}
+
+func (s *FileStore) Save(ctx context.Context, t *Trigger) error {
+	if err := s.MemoryStore.Save(ctx, t); err != nil {
+		return err
+	}
+	return s.flush(ctx)
+}
+
+func (s *FileStore) Delete(ctx context.Context, id string) error {
+	if err := s.MemoryStore.Delete(ctx, id); err != nil {
+		return err
+	}
+	return s.flush(ctx)
+}
+
+func (s *FileStore) flush(ctx context.Context) error {
+	s.flushMtx.Lock()
+	defer s.flushMtx.Unlock()
+
+	triggers, err := s.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(triggers, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
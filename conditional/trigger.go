@@ -0,0 +1,98 @@
+package mexcconditional
+
+import (
+	"errors"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type Kind string
+
+const (
+	KindStopLoss     Kind = "STOP_LOSS"
+	KindTakeProfit   Kind = "TAKE_PROFIT"
+	KindTrailingStop Kind = "TRAILING_STOP"
+)
+
+type TriggerStatus string
+
+const (
+	TriggerStatusPending   TriggerStatus = "PENDING"
+	TriggerStatusTriggered TriggerStatus = "TRIGGERED"
+	TriggerStatusFailed    TriggerStatus = "FAILED"
+)
+
+// Trigger submits child order when price condition is met. Side of child order defines direction:
+// sell stop loss fires when price falls to TriggerPrice, sell take profit when price rises to it,
+// sell trailing stop when price falls by TrailingDelta from the highest price seen. Buy triggers are mirrored.
+type Trigger struct {
+	ID            string                            `json:"id"`
+	Kind          Kind                              `json:"kind"`
+	TriggerPrice  decimal.Decimal                   `json:"triggerPrice,omitempty"`
+	TrailingDelta decimal.Decimal                   `json:"trailingDelta,omitempty"` // fraction, e.g. 0.02 for 2%
+	Extreme       decimal.Decimal                   `json:"extreme,omitempty"`       // best price seen by trailing stop
+	Order         mexchttpmarket.CreateOrderRequest `json:"order"`
+	Status        TriggerStatus                     `json:"status"`
+	CreatedAt     time.Time                         `json:"createdAt"`
+	TriggeredAt   *time.Time                        `json:"triggeredAt,omitempty"`
+	OrderID       string                            `json:"orderId,omitempty"`
+	Error         string                            `json:"error,omitempty"`
+}
+
+func (t *Trigger) validate() error {
+	if t.Order.Symbol == "" {
+		return errors.New("child order symbol is required")
+	}
+	if t.Order.Side != mexchttpmarket.SideBuy && t.Order.Side != mexchttpmarket.SideSell {
+		return fmt.Errorf("unknown child order side %q", t.Order.Side)
+	}
+
+	switch t.Kind {
+	case KindStopLoss, KindTakeProfit:
+		if !t.TriggerPrice.IsPositive() {
+			return fmt.Errorf("%s requires positive trigger price", t.Kind)
+		}
+	case KindTrailingStop:
+		if !t.TrailingDelta.IsPositive() || t.TrailingDelta.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+			return errors.New("trailing delta must be between 0 and 1")
+		}
+	default:
+		return fmt.Errorf("unknown trigger kind %q", t.Kind)
+	}
+
+	return nil
+}
+
+// evaluate checks condition against the price relevant for child order side and reports whether
+// trigger fired and whether its state has changed
+func (t *Trigger) evaluate(price decimal.Decimal) (fired, changed bool) {
+	sell := t.Order.Side == mexchttpmarket.SideSell
+
+	switch t.Kind {
+	case KindStopLoss:
+		if sell {
+			return price.LessThanOrEqual(t.TriggerPrice), false
+		}
+		return price.GreaterThanOrEqual(t.TriggerPrice), false
+	case KindTakeProfit:
+		if sell {
+			return price.GreaterThanOrEqual(t.TriggerPrice), false
+		}
+		return price.LessThanOrEqual(t.TriggerPrice), false
+	case KindTrailingStop:
+		if t.Extreme.IsZero() || (sell && price.GreaterThan(t.Extreme)) || (!sell && price.LessThan(t.Extreme)) {
+			t.Extreme = price
+			return false, true
+		}
+
+		one := decimal.NewFromInt(1)
+		if sell {
+			return price.LessThanOrEqual(t.Extreme.Mul(one.Sub(t.TrailingDelta))), false
+		}
+		return price.GreaterThanOrEqual(t.Extreme.Mul(one.Add(t.TrailingDelta))), false
+	}
+
+	return false, false
+}
@@ -5,17 +5,41 @@ const (
 	// Market
 	EndpointExchangeInfo           = "/api/v3/exchangeInfo"
 	EndpointOrder                  = "/api/v3/order"
+	EndpointOpenOrders             = "/api/v3/openOrders"
+	EndpointBatchOrders            = "/api/v3/batchOrders"
 	EndpointOrderBook              = "/api/v3/depth"
 	EndpointPing                   = "/api/v3/ping"
 	EndpointTime                   = "/api/v3/time"
 	EndpointTradeFee               = "/api/v3/tradeFee"
+	EndpointTickerPrice            = "/api/v3/ticker/price"
+	EndpointTransfer               = "/api/v3/capital/transfer"
 	EndpointInternalTransfer       = "/api/v3/capital/transfer/internal"
 	EndpointUniversalTransfer      = "/api/v3/capital/sub-account/universalTransfer"
 	EndpointWithdraw               = "/api/v3/capital/withdraw"
 	EndpointWithdrawHistory        = "/api/v3/capital/withdraw/history"
+	EndpointWithdrawAddress        = "/api/v3/capital/withdraw/address"
+	EndpointDepositAddress         = "/api/v3/capital/deposit/address"
+	EndpointDepositHistory         = "/api/v3/capital/deposit/hisrec"
+	EndpointConvertList            = "/api/v3/capital/convert/list"
+	EndpointConvert                = "/api/v3/capital/convert"
 	EndpointGetCurrencyInformation = "/api/v3/capital/config/getall"
 	EndpointAccountInformation     = "/api/v3/account"
 	EndpointAccountTradeList       = "/api/v3/myTrades"
+	EndpointUID                    = "/api/v3/uid"
+	EndpointKYCStatus              = "/api/v3/kyc/status"
+	EndpointMXDeduct               = "/api/v3/mxDeduct/enable"
+
+	// Rebate
+	EndpointRebateHistory  = "/api/v3/rebate/taxQuery"
+	EndpointRebateDetail   = "/api/v3/rebate/detail"
+	EndpointRebateKickback = "/api/v3/rebate/detail/kickback"
+	EndpointReferCode      = "/api/v3/rebate/referCode"
+
+	// Sub-account
+	EndpointSubAccountVirtual = "/api/v3/sub-account/virtualSubAccount"
+	EndpointSubAccountList    = "/api/v3/sub-account/list"
+	EndpointSubAccountAPIKey  = "/api/v3/sub-account/apiKey"
+	EndpointSubAccountAsset   = "/api/v3/sub-account/asset"
 
 	// Stream
 	EndpointStream = "/api/v3/userDataStream"
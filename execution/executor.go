@@ -0,0 +1,473 @@
+package mexcexecution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultPollInterval = 500 * time.Millisecond
+	maxQueryFailures    = 5 // consecutive child order query errors before execution fails
+	cancelQueryAttempts = 3
+)
+
+type State string
+
+const (
+	StatePending   State = "PENDING"
+	StateRunning   State = "RUNNING"
+	StatePaused    State = "PAUSED"
+	StateCompleted State = "COMPLETED"
+	StateCancelled State = "CANCELLED"
+	StateFailed    State = "FAILED"
+)
+
+// OrderService is a part of market service used by executors
+type OrderService interface {
+	CreateOrder(ctx context.Context, req *mexchttpmarket.CreateOrderRequest) (*mexchttpmarket.CreateOrderResponse, error)
+	CancelOrder(ctx context.Context, req *mexchttpmarket.CancelOrderRequest) (*mexchttpmarket.CancelOrderResponse, error)
+	QueryOrder(ctx context.Context, req *mexchttpmarket.GetOrderRequest) (*mexchttpmarket.GetOrderResponse, error)
+}
+
+// Config is common for all executors
+type Config struct {
+	Symbol         string
+	Side           mexchttpmarket.Side
+	Quantity       decimal.Decimal
+	QuantityStep   decimal.Decimal                        // child order quantity is rounded down to it, zero disables rounding
+	LimitPrice     *decimal.Decimal                       // max price for buy, min price for sell
+	ClientOrderIDs *mexchttpmarket.ClientOrderIDGenerator // optional
+	PollInterval   time.Duration                          // child order status polling, defaults to DefaultPollInterval
+}
+
+func (c *Config) validate() error {
+	if c.Symbol == "" {
+		return errors.New("symbol is required")
+	}
+	if c.Side != mexchttpmarket.SideBuy && c.Side != mexchttpmarket.SideSell {
+		return fmt.Errorf("unknown side %q", c.Side)
+	}
+	if !c.Quantity.IsPositive() {
+		return errors.New("quantity must be positive")
+	}
+	if c.LimitPrice != nil && !c.LimitPrice.IsPositive() {
+		return errors.New("limit price must be positive")
+	}
+	return nil
+}
+
+// Progress is a snapshot of execution
+type Progress struct {
+	State       State
+	Quantity    decimal.Decimal
+	FilledQty   decimal.Decimal
+	FilledQuote decimal.Decimal
+	AvgPrice    decimal.Decimal
+	ArrivalMid  decimal.Decimal // mid at Start, zero if book wasn't known
+	SlippageBps decimal.Decimal // average price versus arrival mid, positive is cost
+	Orders      int
+	Err         error
+}
+
+// scheduler returns quantity of the next child order, it blocks until the order is due.
+// Zero quantity skips the round.
+type scheduler func(ctx context.Context, e *Executor) (decimal.Decimal, error)
+
+// Executor slices parent order into child orders, it is created by NewTWAP, NewPOV or NewIceberg
+type Executor struct {
+	cfg      Config
+	orders   OrderService
+	market   *MarketData
+	schedule scheduler
+	passive  bool // child orders rest on the book at limit price
+
+	mtx         *sync.Mutex
+	state       State
+	paused      bool
+	resume      chan struct{}
+	filled      decimal.Decimal
+	filledQ     decimal.Decimal
+	arrivalMid  decimal.Decimal
+	childOrders int
+	err         error
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+func newExecutor(orders OrderService, market *MarketData, cfg Config, schedule scheduler, passive bool) (*Executor, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+
+	return &Executor{
+		cfg:      cfg,
+		orders:   orders,
+		market:   market,
+		schedule: schedule,
+		passive:  passive,
+		mtx:      new(sync.Mutex),
+		state:    StatePending,
+		resume:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Start runs execution in background until it is completed, cancelled or ctx is done
+func (e *Executor) Start(ctx context.Context) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if e.state != StatePending {
+		return fmt.Errorf("executor is already %s", e.state)
+	}
+
+	if e.market != nil {
+		if mid, ok := e.market.Mid(); ok {
+			e.arrivalMid = mid
+		}
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	e.cancel = cancel
+	e.state = StateRunning
+	go e.run(runCtx)
+
+	return nil
+}
+
+// Pause stops sending new child orders, resting child order stays on the book
+func (e *Executor) Pause() {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if e.state == StateRunning {
+		e.paused = true
+		e.state = StatePaused
+	}
+}
+
+func (e *Executor) Resume() {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if e.state == StatePaused {
+		e.paused = false
+		e.state = StateRunning
+		close(e.resume)
+		e.resume = make(chan struct{})
+	}
+}
+
+// Cancel stops execution and cancels resting child order, it doesn't wait for completion
+func (e *Executor) Cancel() {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if e.cancel != nil {
+		e.cancel()
+	}
+}
+
+// Done is closed when execution is finished
+func (e *Executor) Done() <-chan struct{} {
+	return e.done
+}
+
+func (e *Executor) Progress() Progress {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	p := Progress{
+		State:       e.state,
+		Quantity:    e.cfg.Quantity,
+		FilledQty:   e.filled,
+		FilledQuote: e.filledQ,
+		ArrivalMid:  e.arrivalMid,
+		Orders:      e.childOrders,
+		Err:         e.err,
+	}
+
+	if e.filled.IsPositive() {
+		p.AvgPrice = e.filledQ.Div(e.filled)
+		if e.arrivalMid.IsPositive() {
+			diff := p.AvgPrice.Sub(e.arrivalMid)
+			if e.cfg.Side == mexchttpmarket.SideSell {
+				diff = diff.Neg()
+			}
+			p.SlippageBps = diff.Div(e.arrivalMid).Mul(decimal.NewFromInt(10000))
+		}
+	}
+
+	return p
+}
+
+func (e *Executor) run(ctx context.Context) {
+	defer close(e.done)
+
+	for {
+		if !e.roundQty(e.remaining()).IsPositive() {
+			e.finish(StateCompleted, nil)
+			return
+		}
+
+		if err := e.waitResumed(ctx); err != nil {
+			e.finish(StateCancelled, nil)
+			return
+		}
+
+		qty, err := e.schedule(ctx, e)
+		if ctx.Err() != nil {
+			e.finish(StateCancelled, nil)
+			return
+		}
+		if err != nil {
+			e.finish(StateFailed, err)
+			return
+		}
+
+		qty = e.roundQty(decimal.Min(qty, e.remaining()))
+		if !qty.IsPositive() {
+			continue
+		}
+
+		order, err := e.sendChild(ctx, qty)
+		if order != nil {
+			e.addFill(order)
+		}
+		if ctx.Err() != nil {
+			e.finish(StateCancelled, nil)
+			return
+		}
+		if err != nil {
+			e.finish(StateFailed, err)
+			return
+		}
+	}
+}
+
+func (e *Executor) waitResumed(ctx context.Context) error {
+	for {
+		e.mtx.Lock()
+		paused, resume := e.paused, e.resume
+		e.mtx.Unlock()
+
+		if !paused {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-resume:
+		}
+	}
+}
+
+func (e *Executor) remaining() decimal.Decimal {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	return e.cfg.Quantity.Sub(e.filled)
+}
+
+func (e *Executor) roundQty(qty decimal.Decimal) decimal.Decimal {
+	if !e.cfg.QuantityStep.IsPositive() {
+		return qty
+	}
+	return qty.Div(e.cfg.QuantityStep).Floor().Mul(e.cfg.QuantityStep)
+}
+
+func (e *Executor) filledQty() decimal.Decimal {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	return e.filled
+}
+
+func (e *Executor) addFill(order *mexchttpmarket.GetOrderResponse) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	e.childOrders++
+	e.filled = e.filled.Add(order.ExecutedQty)
+	e.filledQ = e.filledQ.Add(order.CummulativeQuoteQty)
+}
+
+func (e *Executor) finish(state State, err error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	e.state = state
+	e.err = err
+	e.cancel()
+}
+
+// sendChild places child order and waits until it is final, cancelled ctx cancels resting order
+func (e *Executor) sendChild(ctx context.Context, qty decimal.Decimal) (*mexchttpmarket.GetOrderResponse, error) {
+	req, err := e.childRequest(qty)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		// touch is beyond limit price, quantity is carried over to the next round
+		return nil, nil
+	}
+
+	resp, err := e.orders.CreateOrder(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("create child order: %w", err)
+	}
+	if !resp.IsAck() && resp.Status.IsFinal() {
+		return resp.Order(), nil
+	}
+
+	return e.waitFinal(ctx, resp.OrderId)
+}
+
+// childRequest returns nil request if order can't be sent within limit price
+func (e *Executor) childRequest(qty decimal.Decimal) (*mexchttpmarket.CreateOrderRequest, error) {
+	price, ok := e.childPrice()
+	if !ok {
+		return nil, nil
+	}
+
+	orderType := mexchttpmarket.TypeMarket
+	switch {
+	case price == nil:
+	case e.passive:
+		orderType = mexchttpmarket.TypeLimit
+	default:
+		orderType = mexchttpmarket.TypeImmediateOrCancel
+	}
+
+	b := mexchttpmarket.NewOrderBuilder(e.cfg.Symbol, e.cfg.Side, orderType).
+		Qty(qty).
+		ClientOrderIDs(e.cfg.ClientOrderIDs)
+	if price != nil {
+		b.Price(*price)
+	}
+
+	req, err := b.Build()
+	if err != nil {
+		return nil, fmt.Errorf("build child order: %w", err)
+	}
+	return req, nil
+}
+
+// childPrice returns nil price for market order and false if order can't be sent within limit price
+func (e *Executor) childPrice() (*decimal.Decimal, bool) {
+	limit := e.cfg.LimitPrice
+	if e.passive {
+		return limit, limit != nil
+	}
+
+	var bid, ask decimal.Decimal
+	known := false
+	if e.market != nil {
+		bid, ask, known = e.market.Quote()
+	}
+	if !known {
+		return limit, true
+	}
+
+	touch := ask
+	if e.cfg.Side == mexchttpmarket.SideSell {
+		touch = bid
+	}
+	if limit == nil {
+		return &touch, true
+	}
+
+	if (e.cfg.Side == mexchttpmarket.SideBuy && touch.GreaterThan(*limit)) ||
+		(e.cfg.Side == mexchttpmarket.SideSell && touch.LessThan(*limit)) {
+		return nil, false
+	}
+	return &touch, true
+}
+
+// waitFinal polls child order until it is final. After maxQueryFailures consecutive query errors
+// the order is cancelled and the query error is returned with its final state.
+func (e *Executor) waitFinal(ctx context.Context, orderID string) (*mexchttpmarket.GetOrderResponse, error) {
+	ticker := time.NewTicker(e.cfg.PollInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return e.cancelChild(orderID)
+		case <-ticker.C:
+			order, err := e.query(ctx, orderID)
+			if err != nil {
+				failures++
+				if failures < maxQueryFailures {
+					continue
+				}
+				order, cErr := e.cancelChild(orderID)
+				return order, errors.Join(fmt.Errorf("query child order %s: %w", orderID, err), cErr)
+			}
+			failures = 0
+			if order.Status.IsFinal() {
+				return order, nil
+			}
+		}
+	}
+}
+
+// cancelChild cancels resting child order and returns its final state.
+// Fills are taken from cancel response if the order can't be queried afterwards.
+func (e *Executor) cancelChild(orderID string) (*mexchttpmarket.GetOrderResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*e.cfg.PollInterval)
+	defer cancel()
+
+	cancelled, cErr := e.orders.CancelOrder(ctx, &mexchttpmarket.CancelOrderRequest{Symbol: e.cfg.Symbol, OrderID: &orderID})
+
+	var err error
+	for attempt := 0; attempt < cancelQueryAttempts; attempt++ {
+		if attempt > 0 {
+			if sErr := sleep(ctx, e.cfg.PollInterval); sErr != nil {
+				break
+			}
+		}
+
+		var order *mexchttpmarket.GetOrderResponse
+		order, err = e.query(ctx, orderID)
+		if err == nil {
+			return order, nil
+		}
+	}
+
+	if cErr == nil && cancelled != nil {
+		return cancelled.Order(), nil
+	}
+	return nil, errors.Join(cErr, err)
+}
+
+func (e *Executor) query(ctx context.Context, orderID string) (*mexchttpmarket.GetOrderResponse, error) {
+	return e.orders.QueryOrder(ctx, &mexchttpmarket.GetOrderRequest{Symbol: e.cfg.Symbol, OrderID: &orderID})
+}
+
+// sleep waits for d or ctx
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
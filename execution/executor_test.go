@@ -0,0 +1,167 @@
+package mexcexecution
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOrderService fills IOC orders immediately and keeps limit orders open until cancelled
+type fakeOrderService struct {
+	mtx       sync.Mutex
+	orders    map[string]*mexchttpmarket.GetOrderResponse
+	requests  []*mexchttpmarket.CreateOrderRequest
+	cancelled int
+	partial   decimal.Decimal // executed quantity of new limit orders
+	queryErr  error
+}
+
+func (f *fakeOrderService) CreateOrder(_ context.Context,
+	req *mexchttpmarket.CreateOrderRequest) (*mexchttpmarket.CreateOrderResponse, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.requests = append(f.requests, req)
+	id := strconv.Itoa(len(f.requests))
+	qty := decimal.RequireFromString(*req.Quantity)
+	price := decimal.RequireFromString(*req.Price)
+
+	order := &mexchttpmarket.GetOrderResponse{OrderId: id, Status: mexchttpmarket.StatusNew, OrigQty: qty}
+	if req.Type == mexchttpmarket.TypeImmediateOrCancel {
+		order.Status = mexchttpmarket.StatusFilled
+		order.ExecutedQty = qty
+		order.CummulativeQuoteQty = qty.Mul(price)
+	} else if f.partial.IsPositive() {
+		order.Status = mexchttpmarket.StatusPartiallyFilled
+		order.ExecutedQty = f.partial
+		order.CummulativeQuoteQty = f.partial.Mul(price)
+	}
+	f.orders[id] = order
+
+	return &mexchttpmarket.CreateOrderResponse{OrderId: id}, nil
+}
+
+func (f *fakeOrderService) CancelOrder(_ context.Context,
+	req *mexchttpmarket.CancelOrderRequest) (*mexchttpmarket.CancelOrderResponse, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.cancelled++
+	o := f.orders[*req.OrderID]
+	o.Status = mexchttpmarket.StatusCancelled
+	return &mexchttpmarket.CancelOrderResponse{
+		OrderId:             o.OrderId,
+		Status:              o.Status,
+		ExecutedQty:         o.ExecutedQty,
+		CummulativeQuoteQty: o.CummulativeQuoteQty,
+	}, nil
+}
+
+func (f *fakeOrderService) QueryOrder(_ context.Context,
+	req *mexchttpmarket.GetOrderRequest) (*mexchttpmarket.GetOrderResponse, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.queryErr != nil {
+		return nil, f.queryErr
+	}
+	o := *f.orders[*req.OrderID]
+	return &o, nil
+}
+
+func TestTWAP(t *testing.T) {
+	orders := &fakeOrderService{orders: make(map[string]*mexchttpmarket.GetOrderResponse)}
+	market := NewMarketData("BTCUSDT")
+	market.SetQuote(decimal.NewFromInt(99), decimal.NewFromInt(101))
+
+	limit := decimal.NewFromInt(105)
+	e, err := NewTWAP(orders, market, Config{
+		Symbol:       "BTCUSDT",
+		Side:         mexchttpmarket.SideBuy,
+		Quantity:     decimal.NewFromInt(3),
+		QuantityStep: decimal.RequireFromString("0.01"),
+		LimitPrice:   &limit,
+		PollInterval: time.Millisecond,
+	}, TWAPConfig{Duration: 30 * time.Millisecond, Slices: 3})
+	require.NoError(t, err)
+	require.NoError(t, e.Start(context.Background()))
+
+	select {
+	case <-e.Done():
+	case <-time.After(time.Second):
+		t.Fatal("twap didn't complete")
+	}
+
+	p := e.Progress()
+	assert.Equal(t, StateCompleted, p.State)
+	assert.Equal(t, 3, p.Orders)
+	assert.True(t, decimal.NewFromInt(3).Equal(p.FilledQty))
+	assert.True(t, decimal.NewFromInt(101).Equal(p.AvgPrice))
+	assert.True(t, decimal.NewFromInt(100).Equal(p.SlippageBps), p.SlippageBps.String())
+	assert.Equal(t, "1", *orders.requests[0].Quantity)
+}
+
+func TestIceberg_Cancel(t *testing.T) {
+	orders := &fakeOrderService{orders: make(map[string]*mexchttpmarket.GetOrderResponse)}
+
+	limit := decimal.NewFromInt(100)
+	e, err := NewIceberg(orders, nil, Config{
+		Symbol:       "BTCUSDT",
+		Side:         mexchttpmarket.SideSell,
+		Quantity:     decimal.NewFromInt(10),
+		LimitPrice:   &limit,
+		PollInterval: time.Millisecond,
+	}, IcebergConfig{DisplayQty: decimal.NewFromInt(2)})
+	require.NoError(t, err)
+	require.NoError(t, e.Start(context.Background()))
+
+	time.Sleep(10 * time.Millisecond)
+	e.Cancel()
+	<-e.Done()
+
+	assert.Equal(t, StateCancelled, e.Progress().State)
+	assert.Equal(t, 1, orders.cancelled)
+	assert.Equal(t, mexchttpmarket.TypeLimit, orders.requests[0].Type)
+	assert.Equal(t, "2", *orders.requests[0].Quantity)
+}
+
+func TestExecutor_QueryFailures(t *testing.T) {
+	orders := &fakeOrderService{
+		orders:   make(map[string]*mexchttpmarket.GetOrderResponse),
+		partial:  decimal.NewFromInt(1),
+		queryErr: errors.New("unknown order"),
+	}
+
+	limit := decimal.NewFromInt(100)
+	e, err := NewIceberg(orders, nil, Config{
+		Symbol:       "BTCUSDT",
+		Side:         mexchttpmarket.SideSell,
+		Quantity:     decimal.NewFromInt(10),
+		LimitPrice:   &limit,
+		PollInterval: time.Millisecond,
+	}, IcebergConfig{DisplayQty: decimal.NewFromInt(2)})
+	require.NoError(t, err)
+	require.NoError(t, e.Start(context.Background()))
+
+	select {
+	case <-e.Done():
+	case <-time.After(time.Second):
+		t.Fatal("executor didn't stop on query failures")
+	}
+
+	// partial fill of cancelled child is taken from cancel response
+	p := e.Progress()
+	assert.Equal(t, StateFailed, p.State)
+	assert.ErrorIs(t, p.Err, orders.queryErr)
+	assert.Equal(t, 1, orders.cancelled)
+	assert.True(t, decimal.NewFromInt(1).Equal(p.FilledQty), p.FilledQty.String())
+	assert.True(t, decimal.NewFromInt(100).Equal(p.FilledQuote))
+}
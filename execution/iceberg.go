@@ -0,0 +1,27 @@
+package mexcexecution
+
+import (
+	"context"
+	"errors"
+	"github.com/shopspring/decimal"
+)
+
+type IcebergConfig struct {
+	DisplayQty decimal.Decimal // visible quantity of every child order
+}
+
+// NewIceberg rests child orders of DisplayQty at LimitPrice one after another until parent is filled
+func NewIceberg(orders OrderService, market *MarketData, cfg Config, iceberg IcebergConfig) (*Executor, error) {
+	if cfg.LimitPrice == nil {
+		return nil, errors.New("iceberg requires limit price")
+	}
+	if !iceberg.DisplayQty.IsPositive() || iceberg.DisplayQty.LessThan(cfg.QuantityStep) {
+		return nil, errors.New("display quantity must be positive and not less than quantity step")
+	}
+
+	schedule := func(ctx context.Context, _ *Executor) (decimal.Decimal, error) {
+		return iceberg.DisplayQty, ctx.Err()
+	}
+
+	return newExecutor(orders, market, cfg, schedule, true)
+}
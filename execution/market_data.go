@@ -0,0 +1,104 @@
+package mexcexecution
+
+import (
+	"context"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/market"
+	"github.com/shopspring/decimal"
+	"sync"
+)
+
+// MarketData keeps top of the book and traded volume of a single symbol
+type MarketData struct {
+	symbol string
+	mtx    *sync.RWMutex
+	bid    decimal.Decimal
+	ask    decimal.Decimal
+	volume decimal.Decimal
+}
+
+func NewMarketData(symbol string) *MarketData {
+	return &MarketData{
+		symbol: symbol,
+		mtx:    new(sync.RWMutex),
+	}
+}
+
+// Subscribe feeds market data from partial depth and deals streams
+func (m *MarketData) Subscribe(ctx context.Context, ws *mexcwsmarket.Service) error {
+	symbols := []string{m.symbol}
+	if err := ws.OrderBookSubscribe(ctx, symbols, mexcwsmarket.MinBookDepth, m.HandleDepth); err != nil {
+		return err
+	}
+
+	return ws.DealsSubscribe(ctx, symbols, mexcwsmarket.MaxInterval, m.HandleDeals)
+}
+
+// HandleDepth matches mexcwsmarket.Service.OrderBookSubscribe callback
+func (m *MarketData) HandleDepth(api *dto.PublicLimitDepthsV3Api) {
+	var bid, ask decimal.Decimal
+	if len(api.Bids) > 0 {
+		bid, _ = decimal.NewFromString(api.Bids[0].Price)
+	}
+	if len(api.Asks) > 0 {
+		ask, _ = decimal.NewFromString(api.Asks[0].Price)
+	}
+
+	m.SetQuote(bid, ask)
+}
+
+// HandleDeals matches mexcwsmarket.Service.DealsSubscribe callback
+func (m *MarketData) HandleDeals(api *dto.PublicAggreDealsV3Api, symbol string) {
+	if symbol != "" && symbol != m.symbol {
+		return
+	}
+
+	volume := decimal.Zero
+	for _, deal := range api.Deals {
+		qty, err := decimal.NewFromString(deal.Quantity)
+		if err != nil {
+			continue
+		}
+		volume = volume.Add(qty)
+	}
+
+	m.AddVolume(volume)
+}
+
+func (m *MarketData) SetQuote(bid, ask decimal.Decimal) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.bid, m.ask = bid, ask
+}
+
+func (m *MarketData) AddVolume(qty decimal.Decimal) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.volume = m.volume.Add(qty)
+}
+
+// Quote returns best bid and ask, ok is false until both sides are known
+func (m *MarketData) Quote() (bid, ask decimal.Decimal, ok bool) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return m.bid, m.ask, m.bid.IsPositive() && m.ask.IsPositive()
+}
+
+func (m *MarketData) Mid() (decimal.Decimal, bool) {
+	bid, ask, ok := m.Quote()
+	if !ok {
+		return decimal.Zero, false
+	}
+	return bid.Add(ask).Div(decimal.NewFromInt(2)), true
+}
+
+// Volume returns cumulative traded quantity since market data creation
+func (m *MarketData) Volume() decimal.Decimal {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return m.volume
+}
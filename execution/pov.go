@@ -0,0 +1,55 @@
+package mexcexecution
+
+import (
+	"context"
+	"errors"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type POVConfig struct {
+	Participation decimal.Decimal // share of market volume, e.g. 0.1 for 10%
+	Interval      time.Duration   // how often market volume is checked
+	MinSlice      decimal.Decimal // smaller child orders are postponed
+	MaxSlice      decimal.Decimal // zero means no limit
+}
+
+// NewPOV keeps executed quantity at Participation of volume traded since start, market data is required.
+// It is the volume-weighted executor: child orders follow the market volume curve, so fills track VWAP
+// of the execution period without a historical volume profile.
+func NewPOV(orders OrderService, market *MarketData, cfg Config, pov POVConfig) (*Executor, error) {
+	if market == nil {
+		return nil, errors.New("pov requires market data")
+	}
+	if !pov.Participation.IsPositive() || pov.Participation.GreaterThan(decimal.NewFromInt(1)) {
+		return nil, errors.New("participation must be between 0 and 1")
+	}
+	if pov.Interval <= 0 {
+		return nil, errors.New("pov interval must be positive")
+	}
+
+	var startVolume *decimal.Decimal
+
+	schedule := func(ctx context.Context, e *Executor) (decimal.Decimal, error) {
+		if startVolume == nil {
+			v := market.Volume()
+			startVolume = &v
+		}
+
+		if err := sleep(ctx, pov.Interval); err != nil {
+			return decimal.Zero, err
+		}
+
+		target := market.Volume().Sub(*startVolume).Mul(pov.Participation)
+		qty := target.Sub(e.filledQty())
+		if qty.LessThan(pov.MinSlice) {
+			return decimal.Zero, nil
+		}
+		if pov.MaxSlice.IsPositive() {
+			qty = decimal.Min(qty, pov.MaxSlice)
+		}
+		return qty, nil
+	}
+
+	return newExecutor(orders, market, cfg, schedule, false)
+}
@@ -0,0 +1,42 @@
+package mexcexecution
+
+import (
+	"context"
+	"errors"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type TWAPConfig struct {
+	Duration time.Duration
+	Slices   int
+}
+
+// NewTWAP sends child orders every Duration/Slices, each slice catches up to the linear schedule,
+// so quantity missed because of limit price is sent later. Unfilled rest is retried every slice interval after Duration.
+func NewTWAP(orders OrderService, market *MarketData, cfg Config, twap TWAPConfig) (*Executor, error) {
+	if twap.Duration <= 0 || twap.Slices <= 0 {
+		return nil, errors.New("twap duration and slices must be positive")
+	}
+
+	interval := twap.Duration / time.Duration(twap.Slices)
+	slices := decimal.NewFromInt(int64(twap.Slices))
+	round := 0
+
+	schedule := func(ctx context.Context, e *Executor) (decimal.Decimal, error) {
+		if round > 0 {
+			if err := sleep(ctx, interval); err != nil {
+				return decimal.Zero, err
+			}
+		}
+		round++
+
+		target := e.cfg.Quantity
+		if round < twap.Slices {
+			target = e.cfg.Quantity.Mul(decimal.NewFromInt(int64(round))).Div(slices)
+		}
+		return target.Sub(e.filledQty()), nil
+	}
+
+	return newExecutor(orders, market, cfg, schedule, false)
+}
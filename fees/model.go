@@ -0,0 +1,275 @@
+package mexcfees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRefreshInterval = time.Hour
+)
+
+type Liquidity string
+
+const (
+	LiquidityMaker Liquidity = "MAKER"
+	LiquidityTaker Liquidity = "TAKER"
+)
+
+// FeeService is a part of market service which returns commissions
+type FeeService interface {
+	TradeFee(ctx context.Context, symbol string) (*mexchttpmarket.TradeFeeResponse, error)
+}
+
+// Commission rates of symbol as fractions, e.g. 0.001 is 0.1%
+type Commission struct {
+	Maker     decimal.Decimal
+	Taker     decimal.Decimal
+	UpdatedAt time.Time
+}
+
+type Config struct {
+	RefreshInterval time.Duration    // cached commissions older than interval are refetched, DefaultRefreshInterval if zero
+	MXDiscount      *decimal.Decimal // commission discount when fees are deducted in MX, 0.2 (20%) if nil
+	MXDeduct        bool             // initial MX deduction state, see SetMXDeduct
+}
+
+// Estimate of order commission, Fee is in quote asset and includes MX discount if enabled
+type Estimate struct {
+	Liquidity Liquidity
+	Rate      decimal.Decimal
+	Notional  decimal.Decimal
+	Fee       decimal.Decimal
+}
+
+// Model caches per-symbol commissions and estimates order fees
+type Model struct {
+	rest            FeeService
+	refreshInterval time.Duration
+	mxDiscount      decimal.Decimal
+
+	mtx         *sync.RWMutex
+	commissions map[string]Commission
+	mxDeduct    bool
+}
+
+func NewModel(rest FeeService, cfg Config) *Model {
+	m := &Model{
+		rest:            rest,
+		refreshInterval: cfg.RefreshInterval,
+		mxDiscount:      decimal.New(2, -1),
+		mtx:             new(sync.RWMutex),
+		commissions:     make(map[string]Commission),
+		mxDeduct:        cfg.MXDeduct,
+	}
+	if m.refreshInterval <= 0 {
+		m.refreshInterval = DefaultRefreshInterval
+	}
+	if cfg.MXDiscount != nil {
+		m.mxDiscount = *cfg.MXDiscount
+	}
+
+	return m
+}
+
+// SetMXDeduct updates MX deduction state, e.g. from account MX deduct status
+func (m *Model) SetMXDeduct(enabled bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.mxDeduct = enabled
+}
+
+func (m *Model) MXDeduct() bool {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return m.mxDeduct
+}
+
+// Commission returns cached commission of symbol, it's fetched if missing or stale
+func (m *Model) Commission(ctx context.Context, symbol string) (Commission, error) {
+	m.mtx.RLock()
+	c, ok := m.commissions[symbol]
+	m.mtx.RUnlock()
+
+	if ok && time.Since(c.UpdatedAt) < m.refreshInterval {
+		return c, nil
+	}
+
+	fresh, err := m.fetch(ctx, symbol)
+	if err != nil {
+		if ok {
+			// stale rates are better than none
+			return c, nil
+		}
+		return Commission{}, err
+	}
+
+	return fresh, nil
+}
+
+// Refresh refetches commissions of symbols, all cached symbols if empty
+func (m *Model) Refresh(ctx context.Context, symbols ...string) error {
+	if len(symbols) == 0 {
+		m.mtx.RLock()
+		for symbol := range m.commissions {
+			symbols = append(symbols, symbol)
+		}
+		m.mtx.RUnlock()
+	}
+
+	var errs []error
+	for _, symbol := range symbols {
+		if _, err := m.fetch(ctx, symbol); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run refreshes cached commissions every refresh interval until ctx is done
+func (m *Model) Run(ctx context.Context, errCallback func(error)) {
+	ticker := time.NewTicker(m.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Refresh(ctx); err != nil && errCallback != nil {
+				errCallback(err)
+			}
+		}
+	}
+}
+
+func (m *Model) fetch(ctx context.Context, symbol string) (Commission, error) {
+	resp, err := m.rest.TradeFee(ctx, symbol)
+	if err != nil {
+		return Commission{}, fmt.Errorf("trade fee %s: %w", symbol, err)
+	}
+	if resp.Code != 0 {
+		// data of failed response is empty, caching it would mean zero commission
+		return Commission{}, fmt.Errorf("trade fee %s: code %d: %s", symbol, resp.Code, resp.Message)
+	}
+
+	c := Commission{
+		Maker:     resp.Data.MakerCommission,
+		Taker:     resp.Data.TakerCommission,
+		UpdatedAt: time.Now(),
+	}
+
+	m.mtx.Lock()
+	m.commissions[symbol] = c
+	m.mtx.Unlock()
+
+	return c, nil
+}
+
+// Rate returns effective commission rate of symbol for liquidity including MX discount
+func (m *Model) Rate(ctx context.Context, symbol string, liquidity Liquidity) (decimal.Decimal, error) {
+	c, err := m.Commission(ctx, symbol)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	rate := c.Taker
+	if liquidity == LiquidityMaker {
+		rate = c.Maker
+	}
+	if m.MXDeduct() {
+		rate = rate.Mul(decimal.NewFromInt(1).Sub(m.mxDiscount))
+	}
+
+	return rate, nil
+}
+
+// Estimate returns expected commission of order given the best bid and ask,
+// zero bid or ask means the book side is unknown
+func (m *Model) Estimate(ctx context.Context, req *mexchttpmarket.CreateOrderRequest, bid, ask decimal.Decimal) (*Estimate, error) {
+	price, err := parseOptional(req.Price)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price: %w", err)
+	}
+	qty, err := parseOptional(req.Quantity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quantity: %w", err)
+	}
+	quoteQty, err := parseOptional(req.QuoteOrderQty)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quote quantity: %w", err)
+	}
+
+	liquidity := ExpectedLiquidity(req.Type, req.Side, price, bid, ask)
+
+	// market orders execute around the opposite side of the book
+	execPrice := price
+	if req.Type == mexchttpmarket.TypeMarket || execPrice.IsZero() {
+		execPrice = ask
+		if req.Side == mexchttpmarket.SideSell {
+			execPrice = bid
+		}
+	}
+
+	notional := quoteQty
+	if qty.IsPositive() {
+		if !execPrice.IsPositive() {
+			return nil, fmt.Errorf("no price to estimate %s order notional", req.Symbol)
+		}
+		notional = qty.Mul(execPrice)
+	}
+	if !notional.IsPositive() {
+		return nil, errors.New("quantity or quote quantity is required")
+	}
+
+	rate, err := m.Rate(ctx, req.Symbol, liquidity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Estimate{
+		Liquidity: liquidity,
+		Rate:      rate,
+		Notional:  notional,
+		Fee:       notional.Mul(rate),
+	}, nil
+}
+
+// ExpectedLiquidity classifies order as maker or taker. Limit order crossing the book is a taker,
+// limit order with unknown book is treated as a taker to keep estimate conservative.
+func ExpectedLiquidity(typ mexchttpmarket.Type, side mexchttpmarket.Side, price, bid, ask decimal.Decimal) Liquidity {
+	switch typ {
+	case mexchttpmarket.TypeLimitMaker:
+		return LiquidityMaker
+	case mexchttpmarket.TypeLimit:
+	default:
+		return LiquidityTaker
+	}
+
+	if side == mexchttpmarket.SideBuy {
+		if ask.IsPositive() && price.LessThan(ask) {
+			return LiquidityMaker
+		}
+		return LiquidityTaker
+	}
+
+	if bid.IsPositive() && price.GreaterThan(bid) {
+		return LiquidityMaker
+	}
+	return LiquidityTaker
+}
+
+func parseOptional(s *string) (decimal.Decimal, error) {
+	if s == nil || *s == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(*s)
+}
@@ -0,0 +1,77 @@
+package mexcfees
+
+import (
+	"context"
+	"testing"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFeeService struct {
+	calls int
+	code  int32
+}
+
+func (f *fakeFeeService) TradeFee(_ context.Context, _ string) (*mexchttpmarket.TradeFeeResponse, error) {
+	f.calls++
+	if f.code != 0 {
+		return &mexchttpmarket.TradeFeeResponse{Code: f.code, Message: "symbol not support api"}, nil
+	}
+	return &mexchttpmarket.TradeFeeResponse{Data: mexchttpmarket.TradeFeeData{
+		MakerCommission: decimal.RequireFromString("0.001"),
+		TakerCommission: decimal.RequireFromString("0.002"),
+	}}, nil
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestEstimate(t *testing.T) {
+	rest := &fakeFeeService{}
+	m := NewModel(rest, Config{})
+	ctx := context.Background()
+	bid, ask := decimal.NewFromInt(99), decimal.NewFromInt(101)
+
+	passive := &mexchttpmarket.CreateOrderRequest{Symbol: "BTCUSDT", Side: mexchttpmarket.SideBuy,
+		Type: mexchttpmarket.TypeLimit, Price: strPtr("100"), Quantity: strPtr("2")}
+	est, err := m.Estimate(ctx, passive, bid, ask)
+	require.NoError(t, err)
+	assert.Equal(t, LiquidityMaker, est.Liquidity)
+	assert.Equal(t, "0.2", est.Fee.String())
+
+	crossing := &mexchttpmarket.CreateOrderRequest{Symbol: "BTCUSDT", Side: mexchttpmarket.SideSell,
+		Type: mexchttpmarket.TypeLimit, Price: strPtr("99"), Quantity: strPtr("1")}
+	est, err = m.Estimate(ctx, crossing, bid, ask)
+	require.NoError(t, err)
+	assert.Equal(t, LiquidityTaker, est.Liquidity)
+	assert.Equal(t, "0.198", est.Fee.String())
+
+	m.SetMXDeduct(true)
+	market := &mexchttpmarket.CreateOrderRequest{Symbol: "BTCUSDT", Side: mexchttpmarket.SideBuy,
+		Type: mexchttpmarket.TypeMarket, QuoteOrderQty: strPtr("1000")}
+	est, err = m.Estimate(ctx, market, bid, ask)
+	require.NoError(t, err)
+	assert.Equal(t, LiquidityTaker, est.Liquidity)
+	assert.Equal(t, "1.6", est.Fee.String())
+
+	assert.Equal(t, 1, rest.calls, "commission is cached")
+}
+
+func TestCommission_ErrorCode(t *testing.T) {
+	rest := &fakeFeeService{code: 10007}
+	m := NewModel(rest, Config{})
+	ctx := context.Background()
+
+	_, err := m.Commission(ctx, "BTCUSDT")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "10007")
+
+	// failed response isn't cached
+	rest.code = 0
+	c, err := m.Commission(ctx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, "0.002", c.Taker.String())
+	assert.Equal(t, 2, rest.calls)
+}
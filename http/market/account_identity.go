@@ -0,0 +1,113 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/consts"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+type UIDResponse struct {
+	UID string `json:"uid"`
+}
+
+type KYCStatusResponse struct {
+	Status KYCStatus `json:"status"`
+}
+
+// UID https://mexcdevelop.github.io/apidocs/spot_v3_en/#query-uid
+func (s *Service) UID(ctx context.Context, recvWindow *int64) (*UIDResponse, error) {
+	var resp UIDResponse
+	if err := s.sendAccountQuery(ctx, consts.EndpointUID, recvWindow, &resp); err != nil {
+		return nil, fmt.Errorf("uid: %w", err)
+	}
+	return &resp, nil
+}
+
+// KYCStatus https://mexcdevelop.github.io/apidocs/spot_v3_en/#query-kyc-status
+func (s *Service) KYCStatus(ctx context.Context, recvWindow *int64) (*KYCStatusResponse, error) {
+	var resp KYCStatusResponse
+	if err := s.sendAccountQuery(ctx, consts.EndpointKYCStatus, recvWindow, &resp); err != nil {
+		return nil, fmt.Errorf("kyc status: %w", err)
+	}
+	return &resp, nil
+}
+
+func (s *Service) sendAccountQuery(ctx context.Context, endpoint string, recvWindow *int64, resp any) error {
+	params := map[string]string{
+		"timestamp": s.getTimestamp(),
+	}
+	if recvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *recvWindow)
+	}
+
+	body, err := s.client.SendRequest(ctx, http.MethodGet, endpoint, params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, resp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// PermissionRequirements describes expected API key rights, nil flags aren't checked
+type PermissionRequirements struct {
+	CanTrade    *bool
+	CanWithdraw *bool
+	CanDeposit  *bool
+	Required    []string // permissions which must be present, e.g. SPOT
+	Forbidden   []string // permissions which must be absent
+}
+
+// PermissionError lists all differences between expected and actual key rights
+type PermissionError struct {
+	Mismatches []string
+}
+
+func (e *PermissionError) Error() string {
+	return "api key permissions mismatch: " + strings.Join(e.Mismatches, "; ")
+}
+
+// CheckPermissions returns *PermissionError if key has more or fewer rights than expected
+func (s *Service) CheckPermissions(ctx context.Context, expected PermissionRequirements) error {
+	account, err := s.GetAccountInformation(ctx, AccountInformationRequest{})
+	if err != nil {
+		return err
+	}
+
+	return expected.Check(account)
+}
+
+// Check compares requirements with account information
+func (r PermissionRequirements) Check(account *AccountInformationResponse) error {
+	var mismatches []string
+	flag := func(name string, expected *bool, actual bool) {
+		if expected != nil && *expected != actual {
+			mismatches = append(mismatches, fmt.Sprintf("%s is %t, expected %t", name, actual, *expected))
+		}
+	}
+	flag("canTrade", r.CanTrade, account.CanTrade)
+	flag("canWithdraw", r.CanWithdraw, account.CanWithdraw)
+	flag("canDeposit", r.CanDeposit, account.CanDeposit)
+
+	for _, p := range r.Required {
+		if !slices.Contains(account.Permissions, p) {
+			mismatches = append(mismatches, fmt.Sprintf("permission %s is missing", p))
+		}
+	}
+	for _, p := range r.Forbidden {
+		if slices.Contains(account.Permissions, p) {
+			mismatches = append(mismatches, fmt.Sprintf("permission %s is not allowed", p))
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return &PermissionError{Mismatches: mismatches}
+	}
+	return nil
+}
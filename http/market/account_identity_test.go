@@ -0,0 +1,20 @@
+package mexchttpmarket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionRequirements_Check(t *testing.T) {
+	yes, no := true, false
+	req := PermissionRequirements{CanTrade: &yes, CanWithdraw: &no, Required: []string{"SPOT"}}
+
+	require.NoError(t, req.Check(&AccountInformationResponse{CanTrade: true, Permissions: []string{"SPOT"}}))
+
+	err := req.Check(&AccountInformationResponse{CanTrade: true, CanWithdraw: true})
+	var permErr *PermissionError
+	require.ErrorAs(t, err, &permErr)
+	assert.Equal(t, []string{"canWithdraw is true, expected false", "permission SPOT is missing"}, permErr.Mismatches)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/kattana-io/mexc-golang-sdk/consts"
+	"github.com/shopspring/decimal"
 	"net/http"
 )
 
@@ -23,9 +24,13 @@ type AccountInformationResponse struct {
 }
 
 type Balance struct {
-	Asset  string `json:"asset"`
-	Free   string `json:"free"`
-	Locked string `json:"locked"`
+	Asset  string          `json:"asset"`
+	Free   decimal.Decimal `json:"free"`
+	Locked decimal.Decimal `json:"locked"`
+}
+
+func (b Balance) Total() decimal.Decimal {
+	return b.Free.Add(b.Locked)
 }
 
 func (s *Service) GetAccountInformation(ctx context.Context, req AccountInformationRequest) (*AccountInformationResponse, error) {
@@ -0,0 +1,138 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	MaxAccountTradeListLimit = 100
+	AccountTradeListWindow   = 24 * time.Hour // max time range of a single myTrades call
+)
+
+type AccountTradesRangeRequest struct {
+	Symbol     string
+	StartTime  time.Time
+	EndTime    time.Time
+	RecvWindow *int64
+}
+
+// WalkAccountTrades calls fn with every page of trades of symbol between StartTime and EndTime.
+// Range is split into 24h windows, full pages are continued from the last trade time,
+// trades repeated at page boundaries are skipped. Time paging can't reach trades of a single millisecond
+// beyond MaxAccountTradeListLimit, such millisecond is passed after its first full page.
+func (s *Service) WalkAccountTrades(ctx context.Context, req AccountTradesRangeRequest,
+	fn func([]*GetAccountTradeListResponse) error) error {
+	if !req.EndTime.After(req.StartTime) {
+		return errors.New("end time must be after start time")
+	}
+
+	end := req.EndTime.UnixMilli()
+	limit := int32(MaxAccountTradeListLimit)
+
+	// bounds are inclusive, trades at window boundary may be returned by both windows
+	seen := make(map[string]struct{})
+	for windowStart := req.StartTime.UnixMilli(); windowStart <= end; {
+		windowEnd := min(windowStart+AccountTradeListWindow.Milliseconds(), end)
+
+		from := windowStart
+		for {
+			startTime, endTime := from, windowEnd
+			trades, err := s.GetAccountTradeList(ctx, &GetAccountTradeListRequest{
+				Symbol:     req.Symbol,
+				StartTime:  &startTime,
+				EndTime:    &endTime,
+				Limit:      &limit,
+				RecvWindow: req.RecvWindow,
+			})
+			if err != nil {
+				return fmt.Errorf("account trades %s from %d: %w", req.Symbol, from, err)
+			}
+
+			page := make([]*GetAccountTradeListResponse, 0, len(trades))
+			last := from
+			for _, t := range trades {
+				if _, ok := seen[t.ID]; !ok {
+					seen[t.ID] = struct{}{}
+					page = append(page, t)
+				}
+				last = max(last, t.Time)
+			}
+
+			if len(page) > 0 {
+				if err := fn(page); err != nil {
+					return err
+				}
+			}
+
+			if len(trades) < int(limit) {
+				break
+			}
+			if len(page) == 0 {
+				// the whole page is the same millisecond, continue after it
+				from = last + 1
+			} else {
+				// the next page starts with the last trade time, trades of the same millisecond are deduplicated
+				from = last
+			}
+			if from > windowEnd {
+				break
+			}
+		}
+
+		windowStart = windowEnd + 1
+	}
+
+	return nil
+}
+
+// WalkAllAccountTrades walks trades of all held symbols, see HeldSymbols
+func (s *Service) WalkAllAccountTrades(ctx context.Context, start, end time.Time, quoteAssets []string,
+	fn func([]*GetAccountTradeListResponse) error) error {
+	symbols, err := s.HeldSymbols(ctx, quoteAssets)
+	if err != nil {
+		return err
+	}
+
+	for _, symbol := range symbols {
+		err := s.WalkAccountTrades(ctx, AccountTradesRangeRequest{Symbol: symbol, StartTime: start, EndTime: end}, fn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HeldSymbols returns symbols with non-zero balance of base asset quoted in one of quoteAssets, e.g. USDT
+func (s *Service) HeldSymbols(ctx context.Context, quoteAssets []string) ([]string, error) {
+	account, err := s.GetAccountInformation(ctx, AccountInformationRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[string]struct{})
+	for _, b := range account.Balances {
+		if b.Total().IsPositive() {
+			held[b.Asset] = struct{}{}
+		}
+	}
+
+	info, err := s.ExchangeInfo(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("exchange info: %w", err)
+	}
+
+	symbols := make([]string, 0)
+	for i := range info.Symbols {
+		sym := &info.Symbols[i]
+		if _, ok := held[sym.BaseAsset]; ok && slices.Contains(quoteAssets, sym.QuoteAsset) {
+			symbols = append(symbols, sym.Symbol)
+		}
+	}
+
+	return symbols, nil
+}
@@ -0,0 +1,76 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tradesFake serves myTrades with inclusive time bounds and limit, like the exchange does
+func tradesFake(t *testing.T, trades []*GetAccountTradeListResponse) func(r *http.Request) string {
+	sort.SliceStable(trades, func(i, j int) bool { return trades[i].Time < trades[j].Time })
+
+	return func(r *http.Request) string {
+		q := r.URL.Query()
+		start, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		require.LessOrEqual(t, end-start, AccountTradeListWindow.Milliseconds())
+
+		page := make([]*GetAccountTradeListResponse, 0, limit)
+		for _, trade := range trades {
+			if trade.Time >= start && trade.Time <= end && len(page) < limit {
+				page = append(page, trade)
+			}
+		}
+
+		body, err := json.Marshal(page)
+		require.NoError(t, err)
+		return string(body)
+	}
+}
+
+func TestService_WalkAccountTrades(t *testing.T) {
+	start := time.UnixMilli(1_700_000_000_000)
+	window := AccountTradeListWindow.Milliseconds()
+
+	var trades []*GetAccountTradeListResponse
+	// a busy millisecond with more trades than a page holds
+	for i := range MaxAccountTradeListLimit + 20 {
+		trades = append(trades, &GetAccountTradeListResponse{ID: fmt.Sprintf("busy-%d", i), Time: start.UnixMilli() + 10})
+	}
+	trades = append(trades,
+		&GetAccountTradeListResponse{ID: "after-busy", Time: start.UnixMilli() + 11},
+		&GetAccountTradeListResponse{ID: "boundary", Time: start.UnixMilli() + window},
+		&GetAccountTradeListResponse{ID: "second-window", Time: start.UnixMilli() + window + 5},
+	)
+
+	s, _ := newFakeService(tradesFake(t, trades))
+
+	counts := make(map[string]int)
+	err := s.WalkAccountTrades(context.Background(), AccountTradesRangeRequest{
+		Symbol:    "BTCUSDT",
+		StartTime: start,
+		EndTime:   start.Add(2 * AccountTradeListWindow),
+	}, func(page []*GetAccountTradeListResponse) error {
+		for _, trade := range page {
+			counts[trade.ID]++
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, counts["after-busy"], "trades after saturated millisecond are walked")
+	assert.Equal(t, 1, counts["boundary"], "trade at window boundary is passed once")
+	assert.Equal(t, 1, counts["second-window"])
+	assert.Equal(t, 1, counts["busy-0"])
+	assert.Equal(t, 0, counts[fmt.Sprintf("busy-%d", MaxAccountTradeListLimit)], "unreachable by time paging")
+}
@@ -0,0 +1,89 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/consts"
+	"net/http"
+)
+
+const MaxBatchOrders = 20
+
+// BatchOrders https://mexcdevelop.github.io/apidocs/spot_v3_en/#batch-orders
+func (s *Service) BatchOrders(ctx context.Context, req *BatchOrdersRequest) ([]*BatchOrderResult, error) {
+	if len(req.Orders) == 0 || len(req.Orders) > MaxBatchOrders {
+		return nil, fmt.Errorf("batch must contain from 1 to %d orders, got %d", MaxBatchOrders, len(req.Orders))
+	}
+
+	orders := make([]batchOrder, 0, len(req.Orders))
+	for _, o := range req.Orders {
+		orders = append(orders, batchOrder{
+			Symbol:           o.Symbol,
+			Side:             o.Side,
+			Type:             o.Type,
+			Quantity:         o.Quantity,
+			QuoteOrderQty:    o.QuoteOrderQty,
+			Price:            o.Price,
+			NewClientOrderId: o.NewClientOrderId,
+		})
+	}
+
+	batch, err := json.Marshal(orders)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string)
+
+	params["batchOrders"] = string(batch)
+	params["timestamp"] = s.getTimestamp()
+
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	res, err := s.client.SendRequest(ctx, http.MethodPost, consts.EndpointBatchOrders, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*BatchOrderResult
+	err = json.Unmarshal(res, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// BatchOrdersRequest all orders must have the same symbol, RecvWindow and NewOrderRespType of orders are ignored
+type BatchOrdersRequest struct {
+	Orders     []*CreateOrderRequest
+	RecvWindow *int64
+}
+
+type batchOrder struct {
+	Symbol           string  `json:"symbol"`
+	Side             Side    `json:"side"`
+	Type             Type    `json:"type"`
+	Quantity         *string `json:"quantity,omitempty"`
+	QuoteOrderQty    *string `json:"quoteOrderQty,omitempty"`
+	Price            *string `json:"price,omitempty"`
+	NewClientOrderId *string `json:"newClientOrderId,omitempty"`
+}
+
+// BatchOrderResult has either order fields or error code and message
+type BatchOrderResult struct {
+	Symbol           string `json:"symbol"`
+	OrderId          string `json:"orderId"`
+	OrderListId      int    `json:"orderListId"`
+	NewClientOrderId string `json:"newClientOrderId"`
+	Code             int    `json:"code"`
+	Msg              string `json:"msg"`
+}
+
+// Failed reports whether order was rejected by exchange
+func (r *BatchOrderResult) Failed() bool {
+	return r.Code != 0 && r.OrderId == ""
+}
@@ -0,0 +1,121 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/consts"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"strings"
+)
+
+const MaxCancelSymbols = 5
+
+// CancelOrder https://mexcdevelop.github.io/apidocs/spot_v3_en/#cancel-order
+func (s *Service) CancelOrder(ctx context.Context, req *CancelOrderRequest) (*CancelOrderResponse, error) {
+	if req.OrderID == nil && req.OrigClientOrderId == nil {
+		return nil, fmt.Errorf("orderId or origClientOrderId must be specified")
+	}
+
+	params := make(map[string]string)
+
+	params["symbol"] = req.Symbol
+	params["timestamp"] = s.getTimestamp()
+
+	if req.OrderID != nil {
+		params["orderId"] = *req.OrderID
+	}
+	if req.OrigClientOrderId != nil {
+		params["origClientOrderId"] = *req.OrigClientOrderId
+	}
+	if req.NewClientOrderId != nil {
+		params["newClientOrderId"] = *req.NewClientOrderId
+	}
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	res, err := s.client.SendRequest(ctx, http.MethodDelete, consts.EndpointOrder, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var cancelResponse CancelOrderResponse
+	err = json.Unmarshal(res, &cancelResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cancelResponse, nil
+}
+
+type CancelOrderRequest struct {
+	Symbol            string  `json:"symbol"`
+	OrderID           *string `json:"orderId,omitempty"`
+	OrigClientOrderId *string `json:"origClientOrderId,omitempty"`
+	NewClientOrderId  *string `json:"newClientOrderId,omitempty"`
+	RecvWindow        *int64  `json:"recvWindow,omitempty"`
+}
+
+// CancelOpenOrders https://mexcdevelop.github.io/apidocs/spot_v3_en/#cancel-all-open-orders-on-a-symbol
+func (s *Service) CancelOpenOrders(ctx context.Context, req *CancelOpenOrdersRequest) ([]*CancelOrderResponse, error) {
+	params := make(map[string]string)
+
+	params["symbol"] = strings.Join(req.Symbols, ",")
+	params["timestamp"] = s.getTimestamp()
+
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	res, err := s.client.SendRequest(ctx, http.MethodDelete, consts.EndpointOpenOrders, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []*CancelOrderResponse
+	err = json.Unmarshal(res, &orders)
+	if err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+type CancelOpenOrdersRequest struct {
+	Symbols    []string `json:"symbol"` // up to MaxCancelSymbols symbols
+	RecvWindow *int64   `json:"recvWindow,omitempty"`
+}
+
+type CancelOrderResponse struct {
+	Symbol              string          `json:"symbol"`
+	OrigClientOrderId   string          `json:"origClientOrderId"`
+	OrderId             string          `json:"orderId"`
+	ClientOrderID       string          `json:"clientOrderId"`
+	Price               decimal.Decimal `json:"price"`
+	OrigQty             decimal.Decimal `json:"origQty"`
+	ExecutedQty         decimal.Decimal `json:"executedQty"`
+	CummulativeQuoteQty decimal.Decimal `json:"cummulativeQuoteQty"`
+	Status              Status          `json:"status"`
+	TimeInForce         string          `json:"timeInForce"`
+	Type                Type            `json:"type"`
+	Side                Side            `json:"side"`
+}
+
+// Order converts cancel response into order state, e.g. when the follow-up query fails
+func (r *CancelOrderResponse) Order() *GetOrderResponse {
+	return &GetOrderResponse{
+		Symbol:              r.Symbol,
+		OrderId:             r.OrderId,
+		ClientOrderID:       r.OrigClientOrderId,
+		Price:               r.Price,
+		OrigQty:             r.OrigQty,
+		ExecutedQty:         r.ExecutedQty,
+		CummulativeQuoteQty: r.CummulativeQuoteQty,
+		Status:              r.Status,
+		TimeInForce:         r.TimeInForce,
+		Type:                r.Type,
+		Side:                r.Side,
+	}
+}
@@ -0,0 +1,61 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	mexchttp "github.com/kattana-io/mexc-golang-sdk/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransport records requests of Service and answers them with respond
+type fakeTransport struct {
+	requests []*http.Request
+	respond  func(r *http.Request) string
+}
+
+func (f *fakeTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	f.requests = append(f.requests, r)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(f.respond(r))),
+		Request:    r,
+	}, nil
+}
+
+func (f *fakeTransport) query(i int) url.Values {
+	return f.requests[i].URL.Query()
+}
+
+func newFakeService(respond func(r *http.Request) string) (*Service, *fakeTransport) {
+	transport := &fakeTransport{respond: respond}
+	return &Service{client: mexchttp.NewClient("key", "secret", &http.Client{Transport: transport})}, transport
+}
+
+func TestService_CancelOpenOrders(t *testing.T) {
+	s, transport := newFakeService(func(*http.Request) string {
+		return `[{"symbol":"BTCUSDT","orderId":"1","status":"CANCELED"},{"symbol":"ETHUSDT","orderId":"2","status":"CANCELED"}]`
+	})
+
+	recvWindow := int64(5000)
+	orders, err := s.CancelOpenOrders(context.Background(), &CancelOpenOrdersRequest{
+		Symbols:    []string{"BTCUSDT", "ETHUSDT"},
+		RecvWindow: &recvWindow,
+	})
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, "2", orders[1].OrderId)
+
+	require.Len(t, transport.requests, 1)
+	assert.Equal(t, http.MethodDelete, transport.requests[0].Method)
+	assert.Equal(t, "/api/v3/openOrders", transport.requests[0].URL.Path)
+	assert.Equal(t, "BTCUSDT,ETHUSDT", transport.query(0).Get("symbol"))
+	assert.Equal(t, "5000", transport.query(0).Get("recvWindow"))
+	assert.NotEmpty(t, transport.query(0).Get("timestamp"))
+}
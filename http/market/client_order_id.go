@@ -0,0 +1,130 @@
+package mexchttpmarket
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	MaxClientOrderIDLength = 32
+	clientOrderIDSeparator = "-"
+	replacementSeparator   = "_r"
+	sessionRandomLength    = 4 // base36 symbols of random suffix of session
+)
+
+// ClientOrderIDGenerator generates unique newClientOrderId values in format prefix-strategy-session-sequence.
+// Session is the generator creation time with a random suffix, so ids stay unique across restarts
+// and generators with the same prefix started at the same millisecond.
+type ClientOrderIDGenerator struct {
+	prefix   string
+	strategy string
+	session  string
+	seq      atomic.Uint64
+}
+
+// ClientOrderID is a decoded id produced by ClientOrderIDGenerator
+type ClientOrderID struct {
+	Prefix      string
+	Strategy    string
+	Session     string
+	Sequence    uint64
+	Replacement uint64 // number of cancel-replaces of the original order, see ReplacementClientOrderID
+}
+
+func NewClientOrderIDGenerator(prefix, strategy string) (*ClientOrderIDGenerator, error) {
+	for _, part := range []string{prefix, strategy} {
+		if part == "" {
+			return nil, errors.New("prefix and strategy must not be empty")
+		}
+		if strings.Contains(part, clientOrderIDSeparator) {
+			return nil, fmt.Errorf("%q must not contain %q", part, clientOrderIDSeparator)
+		}
+	}
+
+	g := &ClientOrderIDGenerator{
+		prefix:   prefix,
+		strategy: strategy,
+		session:  newSession(),
+	}
+
+	// reserve room for the sequence, it is encoded in base36 as well
+	if len(g.format(0)) > MaxClientOrderIDLength-6 {
+		return nil, fmt.Errorf("prefix and strategy are too long, client order id is limited to %d symbols", MaxClientOrderIDLength)
+	}
+
+	return g, nil
+}
+
+func newSession() string {
+	random := strconv.FormatUint(rand.Uint64N(pow36(sessionRandomLength)), 36)
+	return strconv.FormatInt(time.Now().UnixMilli(), 36) + strings.Repeat("0", sessionRandomLength-len(random)) + random
+}
+
+func pow36(n int) uint64 {
+	res := uint64(1)
+	for range n {
+		res *= 36
+	}
+	return res
+}
+
+// Next returns the next unique client order id
+func (g *ClientOrderIDGenerator) Next() string {
+	return g.format(g.seq.Add(1))
+}
+
+func (g *ClientOrderIDGenerator) format(seq uint64) string {
+	return strings.Join([]string{g.prefix, g.strategy, g.session, strconv.FormatUint(seq, 36)}, clientOrderIDSeparator)
+}
+
+// ReplacementClientOrderID derives id of order replacing orig, e.g. id_r1 for id and id_r2 for id_r1
+func ReplacementClientOrderID(orig string) (string, error) {
+	base, n := splitReplacement(orig)
+	id := base + replacementSeparator + strconv.FormatUint(n+1, 10)
+	if len(id) > MaxClientOrderIDLength {
+		return "", fmt.Errorf("replacement of client order id %q exceeds %d symbols", orig, MaxClientOrderIDLength)
+	}
+	return id, nil
+}
+
+// splitReplacement returns original id and replacement number, separator without number is a part of id itself
+func splitReplacement(id string) (string, uint64) {
+	i := strings.LastIndex(id, replacementSeparator)
+	if i < 0 {
+		return id, 0
+	}
+
+	n, err := strconv.ParseUint(id[i+len(replacementSeparator):], 10, 64)
+	if err != nil {
+		return id, 0
+	}
+	return id[:i], n
+}
+
+// ParseClientOrderID decodes id generated by ClientOrderIDGenerator, including replacement ids
+func ParseClientOrderID(id string) (*ClientOrderID, error) {
+	base, replacement := splitReplacement(id)
+
+	parts := strings.Split(base, clientOrderIDSeparator)
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("client order id %q has unexpected format", id)
+	}
+
+	seq, err := strconv.ParseUint(parts[3], 36, 64)
+	if err != nil {
+		return nil, fmt.Errorf("client order id %q has invalid sequence: %w", id, err)
+	}
+
+	return &ClientOrderID{
+		Prefix:      parts[0],
+		Strategy:    parts[1],
+		Session:     parts[2],
+		Sequence:    seq,
+		Replacement: replacement,
+	}, nil
+}
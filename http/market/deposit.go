@@ -0,0 +1,133 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/consts"
+	"github.com/shopspring/decimal"
+	"net/http"
+)
+
+type DepositAddressRequest struct {
+	Coin       string  // required
+	Network    *string // optional for list, required for generation
+	RecvWindow *int64  // optional
+}
+
+type DepositAddress struct {
+	Coin    string `json:"coin"`
+	Network string `json:"network"`
+	Address string `json:"address"`
+	Memo    string `json:"memo"`
+}
+
+// GenerateDepositAddress https://mexcdevelop.github.io/apidocs/spot_v3_en/#generate-deposit-address-supporting-network
+func (s *Service) GenerateDepositAddress(ctx context.Context, req DepositAddressRequest) (*DepositAddress, error) {
+	if req.Network == nil {
+		return nil, fmt.Errorf("network is required to generate %s deposit address", req.Coin)
+	}
+
+	body, err := s.client.SendRequest(ctx, http.MethodPost, consts.EndpointDepositAddress, s.depositAddressParams(req))
+	if err != nil {
+		return nil, err
+	}
+
+	var address DepositAddress
+	if err := json.Unmarshal(body, &address); err != nil {
+		return nil, fmt.Errorf("failed to parse deposit address: %w", err)
+	}
+
+	return &address, nil
+}
+
+// GetDepositAddresses https://mexcdevelop.github.io/apidocs/spot_v3_en/#deposit-address-supporting-network
+func (s *Service) GetDepositAddresses(ctx context.Context, req DepositAddressRequest) ([]DepositAddress, error) {
+	body, err := s.client.SendRequest(ctx, http.MethodGet, consts.EndpointDepositAddress, s.depositAddressParams(req))
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []DepositAddress
+	if err := json.Unmarshal(body, &addresses); err != nil {
+		return nil, fmt.Errorf("failed to parse deposit addresses: %w", err)
+	}
+
+	return addresses, nil
+}
+
+func (s *Service) depositAddressParams(req DepositAddressRequest) map[string]string {
+	params := map[string]string{
+		"coin":      req.Coin,
+		"timestamp": s.getTimestamp(),
+	}
+	if req.Network != nil {
+		params["network"] = *req.Network
+	}
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+	return params
+}
+
+type DepositHistoryRequest struct {
+	Coin       *string        // optional
+	Status     *DepositStatus // optional
+	StartTime  *int64         // optional, ms, default 7 days ago
+	EndTime    *int64         // optional, ms
+	Limit      *int32         // optional, default 1000, max 1000
+	RecvWindow *int64         // optional
+}
+
+type DepositRecord struct {
+	Amount        decimal.Decimal `json:"amount"`
+	Coin          string          `json:"coin"`
+	Network       string          `json:"network"`
+	Status        DepositStatus   `json:"status"`
+	Address       string          `json:"address"`
+	TxID          string          `json:"txId"`
+	InsertTime    int64           `json:"insertTime"`
+	UpdateTime    int64           `json:"updateTime"`
+	UnlockConfirm string          `json:"unlockConfirm"`
+	ConfirmTimes  string          `json:"confirmTimes"`
+	Memo          string          `json:"memo"`
+	TransHash     string          `json:"transHash"`
+}
+
+// GetDepositHistory https://mexcdevelop.github.io/apidocs/spot_v3_en/#deposit-history-supporting-network
+func (s *Service) GetDepositHistory(ctx context.Context, req DepositHistoryRequest) ([]DepositRecord, error) {
+	params := map[string]string{
+		"timestamp": s.getTimestamp(),
+	}
+
+	if req.Coin != nil {
+		params["coin"] = *req.Coin
+	}
+	if req.Status != nil {
+		params["status"] = fmt.Sprintf("%d", *req.Status)
+	}
+	if req.StartTime != nil {
+		params["startTime"] = fmt.Sprintf("%d", *req.StartTime)
+	}
+	if req.EndTime != nil {
+		params["endTime"] = fmt.Sprintf("%d", *req.EndTime)
+	}
+	if req.Limit != nil {
+		params["limit"] = fmt.Sprintf("%d", *req.Limit)
+	}
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	body, err := s.client.SendRequest(ctx, http.MethodGet, consts.EndpointDepositHistory, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var deposits []DepositRecord
+	if err := json.Unmarshal(body, &deposits); err != nil {
+		return nil, fmt.Errorf("failed to parse deposit history: %w", err)
+	}
+
+	return deposits, nil
+}
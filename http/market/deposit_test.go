@@ -0,0 +1,92 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_GenerateDepositAddress(t *testing.T) {
+	s, transport := newFakeService(func(*http.Request) string {
+		return `{"coin":"USDT","network":"TRC20","address":"Taddr","memo":""}`
+	})
+	ctx := context.Background()
+
+	_, err := s.GenerateDepositAddress(ctx, DepositAddressRequest{Coin: "USDT"})
+	require.Error(t, err)
+	assert.Empty(t, transport.requests)
+
+	network := "TRC20"
+	recvWindow := int64(5000)
+	address, err := s.GenerateDepositAddress(ctx, DepositAddressRequest{Coin: "USDT", Network: &network, RecvWindow: &recvWindow})
+	require.NoError(t, err)
+	assert.Equal(t, "Taddr", address.Address)
+
+	require.Len(t, transport.requests, 1)
+	assert.Equal(t, http.MethodPost, transport.requests[0].Method)
+	assert.Equal(t, "/api/v3/capital/deposit/address", transport.requests[0].URL.Path)
+	query := transport.query(0)
+	assert.Equal(t, "USDT", query.Get("coin"))
+	assert.Equal(t, "TRC20", query.Get("network"))
+	assert.Equal(t, "5000", query.Get("recvWindow"))
+	assert.NotEmpty(t, query.Get("timestamp"))
+}
+
+func TestService_GetDepositAddresses(t *testing.T) {
+	s, transport := newFakeService(func(*http.Request) string {
+		return `[{"coin":"USDT","network":"TRC20","address":"Taddr"},{"coin":"USDT","network":"ERC20","address":"0xaddr"}]`
+	})
+
+	addresses, err := s.GetDepositAddresses(context.Background(), DepositAddressRequest{Coin: "USDT"})
+	require.NoError(t, err)
+	require.Len(t, addresses, 2)
+	assert.Equal(t, "ERC20", addresses[1].Network)
+
+	assert.Equal(t, http.MethodGet, transport.requests[0].Method)
+	assert.Equal(t, "/api/v3/capital/deposit/address", transport.requests[0].URL.Path)
+	assert.Equal(t, "USDT", transport.query(0).Get("coin"))
+	assert.False(t, transport.query(0).Has("network"))
+}
+
+func TestService_GetDepositHistory(t *testing.T) {
+	s, transport := newFakeService(func(*http.Request) string {
+		return `[
+			{"amount":"100","coin":"USDT","network":"TRC20","status":5,"txId":"d1","insertTime":1700000000000},
+			{"amount":"50","coin":"USDT","network":"TRC20","status":4,"txId":"d2","insertTime":1700000001000},
+			{"amount":"10","coin":"USDT","network":"TRC20","status":9,"txId":"d3","insertTime":1700000002000}
+		]`
+	})
+
+	coin := "USDT"
+	status := DepositStatusSuccess
+	startTime, endTime := int64(1_700_000_000_000), int64(1_700_000_100_000)
+	limit := int32(500)
+	deposits, err := s.GetDepositHistory(context.Background(), DepositHistoryRequest{
+		Coin:      &coin,
+		Status:    &status,
+		StartTime: &startTime,
+		EndTime:   &endTime,
+		Limit:     &limit,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodGet, transport.requests[0].Method)
+	assert.Equal(t, "/api/v3/capital/deposit/hisrec", transport.requests[0].URL.Path)
+	query := transport.query(0)
+	assert.Equal(t, "USDT", query.Get("coin"))
+	assert.Equal(t, "5", query.Get("status"))
+	assert.Equal(t, "1700000000000", query.Get("startTime"))
+	assert.Equal(t, "1700000100000", query.Get("endTime"))
+	assert.Equal(t, "500", query.Get("limit"))
+
+	require.Len(t, deposits, 3)
+	assert.Equal(t, "100", deposits[0].Amount.String())
+	assert.Equal(t, DepositStatusSuccess, deposits[0].Status)
+	assert.True(t, deposits[0].Status.IsCredited())
+	assert.Equal(t, DepositStatusPending, deposits[1].Status)
+	assert.False(t, deposits[1].Status.IsCredited())
+	assert.True(t, deposits[2].Status.IsCredited())
+}
@@ -0,0 +1,270 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/consts"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	MaxDustConvertAssets = 15
+	dustQuoteAsset       = "USDT"
+)
+
+type ConvertibleAsset struct {
+	Asset       string          `json:"asset"`
+	Balance     decimal.Decimal `json:"balance"`
+	ConvertMx   decimal.Decimal `json:"convertMx"`
+	ConvertUsdt decimal.Decimal `json:"convertUsdt"`
+	Code        string          `json:"code"`    // set when asset can't be converted
+	Message     string          `json:"message"` // reason asset can't be converted
+}
+
+// Convertible reports whether exchange accepts asset for conversion
+func (a ConvertibleAsset) Convertible() bool {
+	return a.Code == "" || a.Code == "0" || a.Code == "200"
+}
+
+// ConvertibleAssets returns assets which can be converted into MX
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#get-assets-that-can-be-converted-into-mx
+func (s *Service) ConvertibleAssets(ctx context.Context, recvWindow *int64) ([]ConvertibleAsset, error) {
+	params := map[string]string{
+		"timestamp": s.getTimestamp(),
+	}
+	if recvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *recvWindow)
+	}
+
+	body, err := s.client.SendRequest(ctx, http.MethodGet, consts.EndpointConvertList, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var assets []ConvertibleAsset
+	if err := json.Unmarshal(body, &assets); err != nil {
+		return nil, fmt.Errorf("failed to parse convertible assets: %w", err)
+	}
+
+	return assets, nil
+}
+
+type DustConvertRequest struct {
+	Assets     []string // required, up to MaxDustConvertAssets
+	RecvWindow *int64   // optional
+}
+
+type DustConvertResponse struct {
+	SuccessList  []string        `json:"successList"`
+	FailedList   []string        `json:"failedList"`
+	TotalConvert decimal.Decimal `json:"totalConvert"` // MX received
+	ConvertFee   decimal.Decimal `json:"convertFee"`
+}
+
+// DustConvert converts small balances into MX
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#dust-transfer
+func (s *Service) DustConvert(ctx context.Context, req DustConvertRequest) (*DustConvertResponse, error) {
+	if len(req.Assets) == 0 || len(req.Assets) > MaxDustConvertAssets {
+		return nil, fmt.Errorf("assets count must be from 1 to %d", MaxDustConvertAssets)
+	}
+
+	params := map[string]string{
+		"asset":     strings.Join(req.Assets, ","),
+		"timestamp": s.getTimestamp(),
+	}
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	body, err := s.client.SendRequest(ctx, http.MethodPost, consts.EndpointConvert, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp DustConvertResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse dust convert response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+type DustLogRequest struct {
+	StartTime  *int64 // optional, ms
+	EndTime    *int64 // optional, ms
+	Page       *int32 // optional, default 1
+	Limit      *int32 // optional, default 1, max 1000
+	RecvWindow *int64 // optional
+}
+
+type DustLogResponse struct {
+	Data         []DustLogRecord `json:"data"`
+	TotalRecords int32           `json:"totalRecords"`
+	Page         int32           `json:"page"`
+	TotalPageNum int32           `json:"totalPageNum"`
+}
+
+type DustLogRecord struct {
+	TotalConvert   decimal.Decimal     `json:"totalConvert"`
+	TotalFee       decimal.Decimal     `json:"totalFee"`
+	Time           int64               `json:"time"`
+	ConvertDetails []DustConvertDetail `json:"convertDetails"`
+}
+
+type DustConvertDetail struct {
+	ID      string          `json:"id"`
+	Convert decimal.Decimal `json:"convert"` // MX received
+	Fee     decimal.Decimal `json:"fee"`
+	Amount  decimal.Decimal `json:"amount"` // converted asset amount
+	Time    int64           `json:"time"`
+	Asset   string          `json:"asset"`
+}
+
+// DustLog https://mexcdevelop.github.io/apidocs/spot_v3_en/#dustlog
+func (s *Service) DustLog(ctx context.Context, req DustLogRequest) (*DustLogResponse, error) {
+	params := map[string]string{
+		"timestamp": s.getTimestamp(),
+	}
+	if req.StartTime != nil {
+		params["startTime"] = fmt.Sprintf("%d", *req.StartTime)
+	}
+	if req.EndTime != nil {
+		params["endTime"] = fmt.Sprintf("%d", *req.EndTime)
+	}
+	if req.Page != nil {
+		params["page"] = fmt.Sprintf("%d", *req.Page)
+	}
+	if req.Limit != nil {
+		params["limit"] = fmt.Sprintf("%d", *req.Limit)
+	}
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	body, err := s.client.SendRequest(ctx, http.MethodGet, consts.EndpointConvert, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp DustLogResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse dust log: %w", err)
+	}
+
+	return &resp, nil
+}
+
+type SweepDustRequest struct {
+	ThresholdUSD decimal.Decimal // required, assets valued below threshold are converted
+	Exclude      []string        // optional, assets which are never converted, e.g. MX and USDT
+	BatchSize    int             // optional, MaxDustConvertAssets if zero
+	DryRun       bool            // optional, only select assets
+}
+
+type DustReport struct {
+	Selected      []DustCandidate
+	Unconvertible []ConvertibleAsset // listed assets which exchange refuses to convert, with code and message
+	Converted     []string
+	Failed        []string
+	MXReceived    decimal.Decimal
+	Fee           decimal.Decimal
+}
+
+type DustCandidate struct {
+	Asset    string
+	Balance  decimal.Decimal
+	ValueUSD decimal.Decimal
+}
+
+// SweepDust converts balances valued below threshold into MX. Balances are taken from account information,
+// valued by ASSETUSDT ticker price and limited to assets reported as convertible without error code.
+// Batch errors don't stop the sweep, they are returned joined with the report of converted batches.
+func (s *Service) SweepDust(ctx context.Context, req SweepDustRequest) (*DustReport, error) {
+	if !req.ThresholdUSD.IsPositive() {
+		return nil, errors.New("threshold must be positive")
+	}
+	batchSize := req.BatchSize
+	if batchSize <= 0 || batchSize > MaxDustConvertAssets {
+		batchSize = MaxDustConvertAssets
+	}
+
+	account, err := s.GetAccountInformation(ctx, AccountInformationRequest{})
+	if err != nil {
+		return nil, err
+	}
+	convertible, err := s.ConvertibleAssets(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("convertible assets: %w", err)
+	}
+	tickers, err := s.TickerPrice(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("ticker price: %w", err)
+	}
+
+	report := &DustReport{}
+	allowed := make(map[string]struct{}, len(convertible))
+	for _, a := range convertible {
+		if !a.Convertible() {
+			report.Unconvertible = append(report.Unconvertible, a)
+			continue
+		}
+		allowed[a.Asset] = struct{}{}
+	}
+	prices := make(map[string]decimal.Decimal, len(tickers))
+	for _, t := range tickers {
+		prices[t.Symbol] = t.Price
+	}
+
+	for _, b := range account.Balances {
+		if _, ok := allowed[b.Asset]; !ok || slices.Contains(req.Exclude, b.Asset) {
+			continue
+		}
+		free := b.Free
+		if !free.IsPositive() {
+			continue
+		}
+		price, ok := prices[b.Asset+dustQuoteAsset]
+		if !ok {
+			continue
+		}
+
+		value := free.Mul(price)
+		if value.LessThan(req.ThresholdUSD) {
+			report.Selected = append(report.Selected, DustCandidate{Asset: b.Asset, Balance: free, ValueUSD: value})
+		}
+	}
+	sort.Slice(report.Selected, func(i, j int) bool { return report.Selected[i].Asset < report.Selected[j].Asset })
+
+	if req.DryRun {
+		return report, nil
+	}
+
+	var errs []error
+	for start := 0; start < len(report.Selected); start += batchSize {
+		batch := report.Selected[start:min(start+batchSize, len(report.Selected))]
+		assets := make([]string, 0, len(batch))
+		for _, c := range batch {
+			assets = append(assets, c.Asset)
+		}
+
+		resp, err := s.DustConvert(ctx, DustConvertRequest{Assets: assets})
+		if err != nil {
+			report.Failed = append(report.Failed, assets...)
+			errs = append(errs, fmt.Errorf("convert %s: %w", strings.Join(assets, ","), err))
+			continue
+		}
+
+		report.Converted = append(report.Converted, resp.SuccessList...)
+		report.Failed = append(report.Failed, resp.FailedList...)
+		report.MXReceived = report.MXReceived.Add(resp.TotalConvert)
+		report.Fee = report.Fee.Add(resp.ConvertFee)
+	}
+
+	return report, errors.Join(errs...)
+}
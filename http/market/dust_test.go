@@ -0,0 +1,96 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dustFake(r *http.Request) string {
+	switch r.URL.Path {
+	case "/api/v3/account":
+		return `{"balances":[
+			{"asset":"AAA","free":"1","locked":"0"},
+			{"asset":"BBB","free":"2","locked":"0"},
+			{"asset":"CCC","free":"3","locked":"0"},
+			{"asset":"DDD","free":"100","locked":"0"},
+			{"asset":"MX","free":"1","locked":"0"},
+			{"asset":"EEE","free":"1","locked":"0"},
+			{"asset":"FFF","free":"0","locked":"5"}
+		]}`
+	case "/api/v3/capital/convert/list":
+		return `[
+			{"asset":"AAA"},{"asset":"BBB"},{"asset":"CCC"},{"asset":"DDD"},{"asset":"MX"},{"asset":"FFF"},
+			{"asset":"EEE","code":"30001","message":"asset is suspended"}
+		]`
+	case "/api/v3/ticker/price":
+		return `[
+			{"symbol":"AAAUSDT","price":"0.5"},{"symbol":"BBBUSDT","price":"0.1"},{"symbol":"CCCUSDT","price":"0.2"},
+			{"symbol":"DDDUSDT","price":"1"},{"symbol":"MXUSDT","price":"0.5"},{"symbol":"EEEUSDT","price":"0.1"},
+			{"symbol":"FFFUSDT","price":"0.1"}
+		]`
+	case "/api/v3/capital/convert":
+		assets := strings.Split(r.URL.Query().Get("asset"), ",")
+		return `{"successList":["` + strings.Join(assets, `","`) + `"],"totalConvert":"0.1","convertFee":"0.01"}`
+	}
+	return `{}`
+}
+
+func TestService_SweepDust(t *testing.T) {
+	s, transport := newFakeService(dustFake)
+
+	report, err := s.SweepDust(context.Background(), SweepDustRequest{
+		ThresholdUSD: decimal.NewFromInt(1),
+		Exclude:      []string{"MX"},
+		BatchSize:    2,
+	})
+	require.NoError(t, err)
+
+	// DDD is above threshold, MX is excluded, EEE is refused by exchange, FFF has no free balance
+	var selected []string
+	for _, c := range report.Selected {
+		selected = append(selected, c.Asset)
+	}
+	assert.Equal(t, []string{"AAA", "BBB", "CCC"}, selected)
+	assert.Equal(t, "0.6", report.Selected[2].ValueUSD.String())
+	require.Len(t, report.Unconvertible, 1)
+	assert.Equal(t, "EEE", report.Unconvertible[0].Asset)
+	assert.Equal(t, "asset is suspended", report.Unconvertible[0].Message)
+
+	var batches []string
+	for i, r := range transport.requests {
+		if r.URL.Path == "/api/v3/capital/convert" {
+			assert.Equal(t, http.MethodPost, r.Method)
+			batches = append(batches, transport.query(i).Get("asset"))
+		}
+	}
+	assert.Equal(t, []string{"AAA,BBB", "CCC"}, batches)
+	assert.Equal(t, []string{"AAA", "BBB", "CCC"}, report.Converted)
+	assert.Equal(t, "0.2", report.MXReceived.String())
+	assert.Equal(t, "0.02", report.Fee.String())
+}
+
+func TestService_SweepDust_DryRun(t *testing.T) {
+	s, transport := newFakeService(dustFake)
+
+	// CCC valued exactly at threshold is kept, MX isn't excluded
+	report, err := s.SweepDust(context.Background(), SweepDustRequest{ThresholdUSD: decimal.NewFromFloat(0.6), DryRun: true})
+	require.NoError(t, err)
+	var selected []string
+	for _, c := range report.Selected {
+		selected = append(selected, c.Asset)
+	}
+	assert.Equal(t, []string{"AAA", "BBB", "MX"}, selected)
+	assert.Empty(t, report.Converted)
+	for _, r := range transport.requests {
+		assert.NotEqual(t, "/api/v3/capital/convert", r.URL.Path)
+	}
+
+	_, err = s.SweepDust(context.Background(), SweepDustRequest{})
+	require.Error(t, err)
+}
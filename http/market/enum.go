@@ -13,6 +13,7 @@ const (
 	TypeLimit             Type = "LIMIT"
 	TypeMarket            Type = "MARKET"
 	TypeLimitMarket       Type = "LIMIT_MARKET"
+	TypeLimitMaker        Type = "LIMIT_MAKER" // post only
 	TypeImmediateOrCancel Type = "IMMEDIATE_OR_CANCEL"
 	TypeFillOrKill        Type = "FILL_OR_KILL"
 )
@@ -28,6 +29,19 @@ const (
 	StatusPartiallyCancelled Status = "PARTIALLY_CANCELLED"
 )
 
+// IsFinal reports whether order can't change anymore
+func (s Status) IsFinal() bool {
+	return s == StatusFilled || s == StatusCancelled || s == StatusPartiallyCancelled
+}
+
+type OrderRespType string
+
+const (
+	OrderRespTypeAck    OrderRespType = "ACK"
+	OrderRespTypeResult OrderRespType = "RESULT"
+	OrderRespTypeFull   OrderRespType = "FULL"
+)
+
 type WithdrawStatus int32
 
 const (
@@ -43,6 +57,50 @@ const (
 	WithdrawStatusManual        WithdrawStatus = 10
 )
 
+// IsFinal is true for success, failed and cancelled withdrawals
+func (s WithdrawStatus) IsFinal() bool {
+	return s == WithdrawStatusSuccess || s == WithdrawStatusFailed || s == WithdrawStatusCancel
+}
+
+type DepositStatus int32
+
+const (
+	DepositStatusSmall      DepositStatus = 1 // amount is below minimum deposit
+	DepositStatusTimeDelay  DepositStatus = 2
+	DepositStatusLargeDelay DepositStatus = 3
+	DepositStatusPending    DepositStatus = 4
+	DepositStatusSuccess    DepositStatus = 5 // credited
+	DepositStatusAuditing   DepositStatus = 6
+	DepositStatusRejected   DepositStatus = 7
+	DepositStatusRefund     DepositStatus = 8
+	DepositStatusPreSuccess DepositStatus = 9 // credited, not yet unlocked for withdrawal
+	DepositStatusInvalid    DepositStatus = 10
+	DepositStatusRestricted DepositStatus = 11
+	DepositStatusCompleted  DepositStatus = 12
+)
+
+// IsCredited is true when deposit amount is added to account balance
+func (s DepositStatus) IsCredited() bool {
+	return s == DepositStatusSuccess || s == DepositStatusPreSuccess || s == DepositStatusCompleted
+}
+
+type KYCStatus string
+
+const (
+	KYCStatusUnverified    KYCStatus = "1"
+	KYCStatusPrimary       KYCStatus = "2"
+	KYCStatusAdvanced      KYCStatus = "3"
+	KYCStatusInstitutional KYCStatus = "4"
+)
+
+type AccountType string
+
+const (
+	AccountTypeSpot           AccountType = "SPOT"
+	AccountTypeFutures        AccountType = "FUTURES"
+	AccountTypeIsolatedMargin AccountType = "ISOLATED_MARGIN"
+)
+
 type TransferType int32
 
 const (
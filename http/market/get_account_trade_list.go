@@ -20,7 +20,7 @@ func (s *Service) GetAccountTradeList(ctx context.Context, req *GetAccountTradeL
 		params["orderId"] = *req.OrderID
 	}
 	if req.StartTime != nil {
-		params["startTime"] = fmt.Sprintf("%d", *req.RecvWindow)
+		params["startTime"] = fmt.Sprintf("%d", *req.StartTime)
 	}
 	if req.EndTime != nil {
 		params["endTime"] = fmt.Sprintf("%d", *req.EndTime)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/kattana-io/mexc-golang-sdk/consts"
 	"net/http"
+	"time"
 )
 
 type InternalTransferRequest struct {
@@ -55,12 +56,12 @@ func (s *Service) NewInternalTransfer(ctx context.Context, req InternalTransferR
 }
 
 type InternalTransferHistoryRequest struct {
-	StartTime  *int64  // optional, milliseconds
-	EndTime    *int64  // optional, milliseconds
-	Page       *int    // optional, defaults to 1
-	Limit      *int    // optional, defaults to 10
-	TranId     *string // optional, specific tranId to query
-	RecvWindow *int64  // optional
+	StartTime  *time.Time // optional
+	EndTime    *time.Time // optional
+	Page       *int       // optional, defaults to 1
+	Limit      *int       // optional, defaults to 10
+	TranId     *string    // optional, specific tranId to query
+	RecvWindow *int64     // optional
 }
 
 type InternalTransferRecord struct {
@@ -90,10 +91,10 @@ func (s *Service) GetInternalTransferHistory(ctx context.Context, req InternalTr
 	}
 
 	if req.StartTime != nil {
-		params["startTime"] = fmt.Sprintf("%d", *req.StartTime)
+		params["startTime"] = fmt.Sprintf("%d", req.StartTime.UnixMilli())
 	}
 	if req.EndTime != nil {
-		params["endTime"] = fmt.Sprintf("%d", *req.EndTime)
+		params["endTime"] = fmt.Sprintf("%d", req.EndTime.UnixMilli())
 	}
 	if req.Page != nil {
 		params["page"] = fmt.Sprintf("%d", *req.Page)
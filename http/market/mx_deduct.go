@@ -0,0 +1,99 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/consts"
+	"net/http"
+	"strconv"
+)
+
+type MXDeductRequest struct {
+	RecvWindow *int64 // optional
+}
+
+type SetMXDeductRequest struct {
+	Enable     bool
+	RecvWindow *int64 // optional
+}
+
+type MXDeductResponse struct {
+	Data      MXDeductData `json:"data"`
+	Code      int32        `json:"code"`
+	Message   string       `json:"msg"`
+	Timestamp int64        `json:"timestamp"`
+}
+
+type MXDeductData struct {
+	MXDeductEnable bool `json:"mxDeductEnable"`
+}
+
+// MXDeduct returns whether fees are paid in MX
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#query-mx-deduct-status
+func (s *Service) MXDeduct(ctx context.Context, req MXDeductRequest) (*MXDeductResponse, error) {
+	params := map[string]string{
+		"timestamp": s.getTimestamp(),
+	}
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	return s.sendMXDeduct(ctx, http.MethodGet, params)
+}
+
+// SetMXDeduct enables or disables fee payment in MX
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#enable-mx-deduct
+func (s *Service) SetMXDeduct(ctx context.Context, req SetMXDeductRequest) (*MXDeductResponse, error) {
+	params := map[string]string{
+		"mxDeductEnable": strconv.FormatBool(req.Enable),
+		"timestamp":      s.getTimestamp(),
+	}
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	return s.sendMXDeduct(ctx, http.MethodPost, params)
+}
+
+func (s *Service) sendMXDeduct(ctx context.Context, method string, params map[string]string) (*MXDeductResponse, error) {
+	body, err := s.client.SendRequest(ctx, method, consts.EndpointMXDeduct, params)
+	if err != nil {
+		return nil, fmt.Errorf("mx deduct failed: %w", err)
+	}
+
+	var resp MXDeductResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse mx deduct response: %w", err)
+	}
+	if resp.Code != 0 {
+		return nil, fmt.Errorf("mx deduct failed: code %d: %s", resp.Code, resp.Message)
+	}
+
+	return &resp, nil
+}
+
+// AccountProfile combines account information with MX deduction state
+type AccountProfile struct {
+	*AccountInformationResponse
+	MXDeductEnabled bool
+}
+
+// AccountProfile returns permissions, balances and MX deduction state,
+// e.g. to configure fee model with mexcfees.Model.SetMXDeduct
+func (s *Service) AccountProfile(ctx context.Context, req AccountInformationRequest) (*AccountProfile, error) {
+	account, err := s.GetAccountInformation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	mx, err := s.MXDeduct(ctx, MXDeductRequest{RecvWindow: req.RecvWindow})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountProfile{
+		AccountInformationResponse: account,
+		MXDeductEnabled:            mx.Data.MXDeductEnable,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SetMXDeduct(t *testing.T) {
+	body := `{"data":{"mxDeductEnable":true},"code":0,"msg":"success","timestamp":1}`
+	s, transport := newFakeService(func(*http.Request) string { return body })
+	ctx := context.Background()
+
+	resp, err := s.SetMXDeduct(ctx, SetMXDeductRequest{Enable: true})
+	require.NoError(t, err)
+	assert.True(t, resp.Data.MXDeductEnable)
+	assert.Equal(t, http.MethodPost, transport.requests[0].Method)
+	assert.Equal(t, "true", transport.query(0).Get("mxDeductEnable"))
+
+	body = `{"data":{},"code":730002,"msg":"toggle failed","timestamp":1}`
+	_, err = s.SetMXDeduct(ctx, SetMXDeductRequest{Enable: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "730002")
+}
@@ -0,0 +1,39 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/consts"
+	"net/http"
+)
+
+// OpenOrders https://mexcdevelop.github.io/apidocs/spot_v3_en/#current-open-orders
+func (s *Service) OpenOrders(ctx context.Context, req *OpenOrdersRequest) ([]*GetOrderResponse, error) {
+	params := make(map[string]string)
+
+	params["symbol"] = req.Symbol
+	params["timestamp"] = s.getTimestamp()
+
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	res, err := s.client.SendRequest(ctx, http.MethodGet, consts.EndpointOpenOrders, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []*GetOrderResponse
+	err = json.Unmarshal(res, &orders)
+	if err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+type OpenOrdersRequest struct {
+	Symbol     string `json:"symbol"`
+	RecvWindow *int64 `json:"recvWindow,omitempty"`
+}
@@ -30,6 +30,9 @@ func (s *Service) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*Cr
 	if req.NewClientOrderId != nil {
 		params["newClientOrderId"] = *req.NewClientOrderId
 	}
+	if req.NewOrderRespType != nil {
+		params["newOrderRespType"] = string(*req.NewOrderRespType)
+	}
 	if req.RecvWindow != nil {
 		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
 	}
@@ -49,23 +52,69 @@ func (s *Service) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*Cr
 }
 
 type CreateOrderRequest struct {
-	Symbol           string  `json:"symbol"`
-	Side             Side    `json:"side"`
-	Type             Type    `json:"type"`
-	Quantity         *string `json:"quantity,omitempty"`
-	QuoteOrderQty    *string `json:"quoteOrderQty,omitempty"`
-	Price            *string `json:"price,omitempty"`
-	NewClientOrderId *string `json:"newClientOrderId,omitempty"`
-	RecvWindow       *int64  `json:"recvWindow,omitempty"`
+	Symbol           string         `json:"symbol"`
+	Side             Side           `json:"side"`
+	Type             Type           `json:"type"`
+	Quantity         *string        `json:"quantity,omitempty"`
+	QuoteOrderQty    *string        `json:"quoteOrderQty,omitempty"`
+	Price            *string        `json:"price,omitempty"`
+	NewClientOrderId *string        `json:"newClientOrderId,omitempty"`
+	NewOrderRespType *OrderRespType `json:"newOrderRespType,omitempty"`
+	RecvWindow       *int64         `json:"recvWindow,omitempty"`
 }
 
+// CreateOrderResponse contains ack fields only unless RESULT or FULL response type is requested
 type CreateOrderResponse struct {
-	Symbol       string          `json:"symbol"`
-	OrderId      string          `json:"orderId"`
-	OrderListId  int             `json:"orderListId"`
-	Price        decimal.Decimal `json:"price"`
-	OrigQty      decimal.Decimal `json:"origQty"`
-	Type         Type            `json:"type"`
-	Side         Side            `json:"side"`
-	TransactTime int64           `json:"transactTime"`
+	Symbol              string          `json:"symbol"`
+	OrderId             string          `json:"orderId"`
+	OrderListId         int             `json:"orderListId"`
+	ClientOrderID       string          `json:"clientOrderId"`
+	Price               decimal.Decimal `json:"price"`
+	OrigQty             decimal.Decimal `json:"origQty"`
+	Type                Type            `json:"type"`
+	Side                Side            `json:"side"`
+	TransactTime        int64           `json:"transactTime"`
+	ExecutedQty         decimal.Decimal `json:"executedQty"`
+	CummulativeQuoteQty decimal.Decimal `json:"cummulativeQuoteQty"`
+	Status              Status          `json:"status"`
+	TimeInForce         string          `json:"timeInForce"`
+	StopPrice           decimal.Decimal `json:"stopPrice"`
+	OrigQuoteOrderQty   decimal.Decimal `json:"origQuoteOrderQty"`
+	Fills               []OrderFill     `json:"fills,omitempty"`
+}
+
+// OrderFill is returned with FULL response type only
+type OrderFill struct {
+	Price           decimal.Decimal `json:"price"`
+	Qty             decimal.Decimal `json:"qty"`
+	Commission      decimal.Decimal `json:"commission"`
+	CommissionAsset string          `json:"commissionAsset"`
+	TradeID         string          `json:"tradeId"`
+}
+
+// IsAck reports whether exchange returned acknowledgement only and order status is unknown
+func (r *CreateOrderResponse) IsAck() bool {
+	return r.Status == ""
+}
+
+// Order converts response into GetOrderResponse, status fields are empty for ack responses
+func (r *CreateOrderResponse) Order() *GetOrderResponse {
+	return &GetOrderResponse{
+		Symbol:              r.Symbol,
+		OrderId:             r.OrderId,
+		ClientOrderID:       r.ClientOrderID,
+		Price:               r.Price,
+		OrigQty:             r.OrigQty,
+		ExecutedQty:         r.ExecutedQty,
+		CummulativeQuoteQty: r.CummulativeQuoteQty,
+		Status:              r.Status,
+		TimeInForce:         r.TimeInForce,
+		Type:                r.Type,
+		Side:                r.Side,
+		StopPrice:           r.StopPrice,
+		CreateTime:          r.TransactTime,
+		UpdateTime:          r.TransactTime,
+		IsWorking:           r.Status == StatusNew || r.Status == StatusPartiallyFilled,
+		OrigQuoteOrderQty:   r.OrigQuoteOrderQty,
+	}
 }
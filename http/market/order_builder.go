@@ -0,0 +1,271 @@
+package mexchttpmarket
+
+import (
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"slices"
+)
+
+// OrderBuilder builds CreateOrderRequest from decimal values, e.g.
+//
+//	NewLimitBuy("BTCUSDT").Qty(qty).Price(price).PostOnly().Build()
+type OrderBuilder struct {
+	symbol        string
+	side          Side
+	orderType     Type
+	quantity      *decimal.Decimal
+	quoteOrderQty *decimal.Decimal
+	price         *decimal.Decimal
+	postOnly      bool
+	clientOrderID *string
+	respType      *OrderRespType
+	recvWindow    *int64
+	ids           *ClientOrderIDGenerator
+	rules         *Symbol
+}
+
+func NewOrderBuilder(symbol string, side Side, orderType Type) *OrderBuilder {
+	return &OrderBuilder{
+		symbol:    symbol,
+		side:      side,
+		orderType: orderType,
+	}
+}
+
+func NewLimitBuy(symbol string) *OrderBuilder {
+	return NewOrderBuilder(symbol, SideBuy, TypeLimit)
+}
+
+func NewLimitSell(symbol string) *OrderBuilder {
+	return NewOrderBuilder(symbol, SideSell, TypeLimit)
+}
+
+func NewMarketBuy(symbol string) *OrderBuilder {
+	return NewOrderBuilder(symbol, SideBuy, TypeMarket)
+}
+
+func NewMarketSell(symbol string) *OrderBuilder {
+	return NewOrderBuilder(symbol, SideSell, TypeMarket)
+}
+
+// Qty sets order quantity in base asset
+func (b *OrderBuilder) Qty(qty decimal.Decimal) *OrderBuilder {
+	b.quantity = &qty
+	return b
+}
+
+// QuoteQty sets order amount in quote asset, market orders only
+func (b *OrderBuilder) QuoteQty(qty decimal.Decimal) *OrderBuilder {
+	b.quoteOrderQty = &qty
+	return b
+}
+
+func (b *OrderBuilder) Price(price decimal.Decimal) *OrderBuilder {
+	b.price = &price
+	return b
+}
+
+// PostOnly turns limit order into LIMIT_MAKER, it is rejected instead of taking liquidity.
+// Build fails if order isn't a limit one.
+func (b *OrderBuilder) PostOnly() *OrderBuilder {
+	b.postOnly = true
+	return b
+}
+
+func (b *OrderBuilder) ImmediateOrCancel() *OrderBuilder {
+	b.orderType = TypeImmediateOrCancel
+	return b
+}
+
+func (b *OrderBuilder) FillOrKill() *OrderBuilder {
+	b.orderType = TypeFillOrKill
+	return b
+}
+
+// ClientOrderID sets explicit newClientOrderId, takes precedence over ClientOrderIDs
+func (b *OrderBuilder) ClientOrderID(id string) *OrderBuilder {
+	b.clientOrderID = &id
+	return b
+}
+
+// ClientOrderIDs sets generator used to fill newClientOrderId
+func (b *OrderBuilder) ClientOrderIDs(gen *ClientOrderIDGenerator) *OrderBuilder {
+	b.ids = gen
+	return b
+}
+
+// RespType requests ACK, RESULT or FULL response
+func (b *OrderBuilder) RespType(t OrderRespType) *OrderBuilder {
+	b.respType = &t
+	return b
+}
+
+func (b *OrderBuilder) RecvWindow(ms int64) *OrderBuilder {
+	b.recvWindow = &ms
+	return b
+}
+
+// Normalize enables price and quantity normalisation against symbol rules from ExchangeInfo
+func (b *OrderBuilder) Normalize(rules *Symbol) *OrderBuilder {
+	b.rules = rules
+	return b
+}
+
+// Build validates parameters and produces CreateOrderRequest
+func (b *OrderBuilder) Build() (*CreateOrderRequest, error) {
+	if b.postOnly {
+		if b.orderType != TypeLimit && b.orderType != TypeLimitMaker {
+			return nil, fmt.Errorf("%s order can't be post only", b.orderType)
+		}
+		b.orderType = TypeLimitMaker
+	}
+
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	if b.rules != nil {
+		if err := b.normalize(); err != nil {
+			return nil, err
+		}
+	}
+
+	req := &CreateOrderRequest{
+		Symbol:           b.symbol,
+		Side:             b.side,
+		Type:             b.orderType,
+		Quantity:         decimalString(b.quantity),
+		QuoteOrderQty:    decimalString(b.quoteOrderQty),
+		Price:            decimalString(b.price),
+		NewOrderRespType: b.respType,
+		RecvWindow:       b.recvWindow,
+	}
+
+	switch {
+	case b.clientOrderID != nil:
+		req.NewClientOrderId = b.clientOrderID
+	case b.ids != nil:
+		id := b.ids.Next()
+		req.NewClientOrderId = &id
+	}
+
+	return req, nil
+}
+
+func (b *OrderBuilder) validate() error {
+	if b.symbol == "" {
+		return errors.New("symbol is required")
+	}
+	if b.side != SideBuy && b.side != SideSell {
+		return fmt.Errorf("unknown order side %q", b.side)
+	}
+	if b.clientOrderID != nil && len(*b.clientOrderID) > MaxClientOrderIDLength {
+		return fmt.Errorf("client order id is limited to %d symbols", MaxClientOrderIDLength)
+	}
+
+	if b.quantity != nil && !b.quantity.IsPositive() {
+		return fmt.Errorf("quantity must be positive, got %s", b.quantity)
+	}
+	if b.quoteOrderQty != nil && !b.quoteOrderQty.IsPositive() {
+		return fmt.Errorf("quote quantity must be positive, got %s", b.quoteOrderQty)
+	}
+	if b.price != nil && !b.price.IsPositive() {
+		return fmt.Errorf("price must be positive, got %s", b.price)
+	}
+
+	if b.orderType == TypeMarket {
+		if (b.quantity == nil) == (b.quoteOrderQty == nil) {
+			return errors.New("market order requires either quantity or quote quantity")
+		}
+		return nil
+	}
+
+	if b.quantity == nil {
+		return fmt.Errorf("%s order requires quantity", b.orderType)
+	}
+	if b.price == nil {
+		return fmt.Errorf("%s order requires price", b.orderType)
+	}
+	if b.quoteOrderQty != nil {
+		return errors.New("quote quantity is allowed for market orders only")
+	}
+
+	return nil
+}
+
+// normalize rounds price to the passive side and truncates quantity, then checks amount limits
+func (b *OrderBuilder) normalize() error {
+	r := b.rules
+	if r.Symbol != b.symbol {
+		return fmt.Errorf("rules for %s can't be applied to %s", r.Symbol, b.symbol)
+	}
+	if len(r.OrderTypes) > 0 && !slices.Contains(r.OrderTypes, string(b.orderType)) {
+		return fmt.Errorf("order type %s is not allowed for %s", b.orderType, b.symbol)
+	}
+
+	if b.price != nil {
+		price := b.price.RoundFloor(int32(r.QuotePrecision))
+		if b.side == SideSell {
+			price = b.price.RoundCeil(int32(r.QuotePrecision))
+		}
+		if !price.IsPositive() {
+			return fmt.Errorf("price %s is below %s tick", b.price, b.symbol)
+		}
+		b.price = &price
+	}
+
+	if b.quantity != nil {
+		qty := b.quantity.Truncate(int32(r.BaseAssetPrecision))
+		if step, err := decimal.NewFromString(r.BaseSizePrecision); err == nil && step.IsPositive() {
+			qty = qty.Div(step).Floor().Mul(step)
+		}
+		if !qty.IsPositive() {
+			return fmt.Errorf("quantity %s is below %s lot size", b.quantity, b.symbol)
+		}
+		b.quantity = &qty
+	}
+
+	if b.quoteOrderQty != nil {
+		qty := b.quoteOrderQty.Truncate(int32(r.QuoteAssetPrecision))
+		b.quoteOrderQty = &qty
+	}
+
+	return b.checkAmount()
+}
+
+func (b *OrderBuilder) checkAmount() error {
+	minAmount, maxAmount := b.rules.QuoteAmountPrecision, b.rules.MaxQuoteAmount
+	if b.orderType == TypeMarket {
+		minAmount, maxAmount = b.rules.QuoteAmountPrecisionMarket, b.rules.MaxQuoteAmountMarket
+	}
+
+	var amount decimal.Decimal
+	switch {
+	case b.quoteOrderQty != nil:
+		amount = *b.quoteOrderQty
+	case b.price != nil:
+		amount = b.quantity.Mul(*b.price)
+	default:
+		// market order by quantity, amount is unknown before execution
+		return nil
+	}
+
+	if v, err := decimal.NewFromString(minAmount); err == nil && amount.LessThan(v) {
+		return fmt.Errorf("order amount %s is less than %s minimum %s", amount, b.symbol, v)
+	}
+	if v, err := decimal.NewFromString(maxAmount); err == nil && v.IsPositive() && amount.GreaterThan(v) {
+		return fmt.Errorf("order amount %s is greater than %s maximum %s", amount, b.symbol, v)
+	}
+
+	return nil
+}
+
+func decimalString(d *decimal.Decimal) *string {
+	if d == nil {
+		return nil
+	}
+
+	s := d.String()
+	return &s
+}
@@ -0,0 +1,130 @@
+package mexchttpmarket
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderBuilder_LimitPostOnly(t *testing.T) {
+	gen, err := NewClientOrderIDGenerator("kt", "mm")
+	require.NoError(t, err)
+
+	req, err := NewLimitBuy("BTCUSDT").
+		Qty(decimal.RequireFromString("0.5")).
+		Price(decimal.RequireFromString("60000.1")).
+		PostOnly().
+		ClientOrderIDs(gen).
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, TypeLimitMaker, req.Type)
+	assert.Equal(t, SideBuy, req.Side)
+	assert.Equal(t, "0.5", *req.Quantity)
+	assert.Equal(t, "60000.1", *req.Price)
+	assert.Nil(t, req.QuoteOrderQty)
+	require.NotNil(t, req.NewClientOrderId)
+	assert.LessOrEqual(t, len(*req.NewClientOrderId), MaxClientOrderIDLength)
+
+	id, err := ParseClientOrderID(*req.NewClientOrderId)
+	require.NoError(t, err)
+	assert.Equal(t, "kt", id.Prefix)
+	assert.Equal(t, "mm", id.Strategy)
+	assert.Equal(t, uint64(1), id.Sequence)
+	assert.NotEqual(t, *req.NewClientOrderId, gen.Next())
+}
+
+func TestOrderBuilder_Validation(t *testing.T) {
+	_, err := NewLimitSell("BTCUSDT").Qty(decimal.NewFromInt(1)).Build()
+	assert.Error(t, err)
+
+	_, err = NewMarketBuy("BTCUSDT").Build()
+	assert.Error(t, err)
+
+	_, err = NewMarketBuy("BTCUSDT").Qty(decimal.NewFromInt(-1)).Build()
+	assert.Error(t, err)
+
+	_, err = NewMarketBuy("BTCUSDT").QuoteQty(decimal.NewFromInt(100)).PostOnly().Build()
+	assert.Error(t, err, "market order can't be post only")
+
+	req, err := NewMarketBuy("BTCUSDT").QuoteQty(decimal.NewFromInt(100)).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "100", *req.QuoteOrderQty)
+	assert.Nil(t, req.Quantity)
+}
+
+func TestOrderBuilder_Normalize(t *testing.T) {
+	rules := &Symbol{
+		Symbol:               "BTCUSDT",
+		BaseAssetPrecision:   6,
+		QuotePrecision:       2,
+		QuoteAssetPrecision:  2,
+		BaseSizePrecision:    "0.0001",
+		QuoteAmountPrecision: "5",
+		MaxQuoteAmount:       "2000000",
+		OrderTypes:           []string{"LIMIT", "MARKET", "LIMIT_MAKER"},
+	}
+
+	req, err := NewLimitSell("BTCUSDT").
+		Qty(decimal.RequireFromString("0.123456")).
+		Price(decimal.RequireFromString("60000.123")).
+		Normalize(rules).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, "0.1234", *req.Quantity)
+	assert.Equal(t, "60000.13", *req.Price)
+
+	req, err = NewLimitBuy("BTCUSDT").
+		Qty(decimal.RequireFromString("0.1")).
+		Price(decimal.RequireFromString("60000.129")).
+		Normalize(rules).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, "60000.12", *req.Price)
+
+	_, err = NewLimitBuy("BTCUSDT").
+		Qty(decimal.RequireFromString("0.00001")).
+		Price(decimal.NewFromInt(60000)).
+		Normalize(rules).
+		Build()
+	assert.Error(t, err, "quantity below lot size")
+
+	_, err = NewLimitBuy("BTCUSDT").
+		Qty(decimal.RequireFromString("0.0001")).
+		Price(decimal.NewFromInt(100)).
+		Normalize(rules).
+		Build()
+	assert.Error(t, err, "amount below minimum")
+
+	_, err = NewLimitBuy("BTCUSDT").
+		Qty(decimal.RequireFromString("1")).
+		Price(decimal.NewFromInt(100)).
+		FillOrKill().
+		Normalize(rules).
+		Build()
+	assert.Error(t, err, "order type not allowed")
+}
+
+func TestClientOrderIDGenerator(t *testing.T) {
+	_, err := NewClientOrderIDGenerator("a-b", "mm")
+	assert.Error(t, err)
+
+	_, err = NewClientOrderIDGenerator("verylongprefix", "verylongstrategy")
+	assert.Error(t, err)
+
+	_, err = ParseClientOrderID("random")
+	assert.Error(t, err)
+
+	// generators started at the same millisecond differ by random part of session
+	sessions := make(map[string]struct{})
+	for range 20 {
+		gen, err := NewClientOrderIDGenerator("kt", "mm")
+		require.NoError(t, err)
+		id, err := ParseClientOrderID(gen.Next())
+		require.NoError(t, err)
+		sessions[id.Session] = struct{}{}
+	}
+	assert.Greater(t, len(sessions), 1)
+}
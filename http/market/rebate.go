@@ -0,0 +1,205 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/consts"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"time"
+)
+
+const (
+	RebateHistoryWindow = 30 * 24 * time.Hour // max time range of a single rebate call
+)
+
+type RebateRequest struct {
+	StartTime  *time.Time // optional
+	EndTime    *time.Time // optional
+	Page       *int       // optional, default 1
+	RecvWindow *int64     // optional
+}
+
+type RebateHistoryResponse struct {
+	Page         int             `json:"page"`
+	TotalRecords int             `json:"totalRecords"`
+	TotalPageNum int             `json:"totalPageNum"`
+	Data         []RebateHistory `json:"data"`
+}
+
+// RebateHistory is a rebate of invited user in USDT
+type RebateHistory struct {
+	Spot    decimal.Decimal `json:"spot"`
+	Futures decimal.Decimal `json:"futures"`
+	Total   decimal.Decimal `json:"total"`
+	UID     string          `json:"uid"`
+	Account string          `json:"account"`
+	Time    int64           `json:"time"`
+}
+
+type RebateDetailResponse struct {
+	Page         int            `json:"page"`
+	TotalRecords int            `json:"totalRecords"`
+	TotalPageNum int            `json:"totalPageNum"`
+	Data         []RebateDetail `json:"data"`
+}
+
+type RebateDetail struct {
+	Asset      string          `json:"asset"`
+	Type       string          `json:"type"` // spot or futures
+	Rate       decimal.Decimal `json:"rate"`
+	Amount     decimal.Decimal `json:"amount"`
+	UID        string          `json:"uid"`
+	Account    string          `json:"account"`
+	TradeTime  int64           `json:"tradeTime"`
+	UpdateTime int64           `json:"updateTime"`
+}
+
+type ReferCodeResponse struct {
+	ReferCode string `json:"referCode"`
+}
+
+// RebateHistory https://mexcdevelop.github.io/apidocs/spot_v3_en/#get-rebate-history-records
+func (s *Service) RebateHistory(ctx context.Context, req RebateRequest) (*RebateHistoryResponse, error) {
+	var resp RebateHistoryResponse
+	if err := s.sendRebate(ctx, consts.EndpointRebateHistory, s.rebateParams(req), &resp); err != nil {
+		return nil, fmt.Errorf("rebate history: %w", err)
+	}
+	return &resp, nil
+}
+
+// RebateDetail https://mexcdevelop.github.io/apidocs/spot_v3_en/#get-rebate-records-detail
+func (s *Service) RebateDetail(ctx context.Context, req RebateRequest) (*RebateDetailResponse, error) {
+	var resp RebateDetailResponse
+	if err := s.sendRebate(ctx, consts.EndpointRebateDetail, s.rebateParams(req), &resp); err != nil {
+		return nil, fmt.Errorf("rebate detail: %w", err)
+	}
+	return &resp, nil
+}
+
+// SelfRebateDetail returns rebates of own trades
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#get-self-rebate-records-detail
+func (s *Service) SelfRebateDetail(ctx context.Context, req RebateRequest) (*RebateDetailResponse, error) {
+	var resp RebateDetailResponse
+	if err := s.sendRebate(ctx, consts.EndpointRebateKickback, s.rebateParams(req), &resp); err != nil {
+		return nil, fmt.Errorf("self rebate detail: %w", err)
+	}
+	return &resp, nil
+}
+
+// ReferCode https://mexcdevelop.github.io/apidocs/spot_v3_en/#query-refercode
+func (s *Service) ReferCode(ctx context.Context, recvWindow *int64) (*ReferCodeResponse, error) {
+	var resp ReferCodeResponse
+	if err := s.sendRebate(ctx, consts.EndpointReferCode, s.rebateParams(RebateRequest{RecvWindow: recvWindow}), &resp); err != nil {
+		return nil, fmt.Errorf("refer code: %w", err)
+	}
+	return &resp, nil
+}
+
+// RebateRange selects rebates between Start and End, range is split into RebateHistoryWindow windows
+type RebateRange struct {
+	Start      time.Time // required
+	End        time.Time // required
+	RecvWindow *int64    // optional
+}
+
+// WalkRebateHistory calls fn with every page of rebate history in range
+func (s *Service) WalkRebateHistory(ctx context.Context, r RebateRange, fn func([]RebateHistory) error) error {
+	return walkRebatePages(r, func(req RebateRequest) (int, int, error) {
+		resp, err := s.RebateHistory(ctx, req)
+		if err != nil {
+			return 0, 0, err
+		}
+		if len(resp.Data) > 0 {
+			if err := fn(resp.Data); err != nil {
+				return 0, 0, err
+			}
+		}
+		return len(resp.Data), resp.TotalPageNum, nil
+	})
+}
+
+// WalkRebateDetails calls fn with every page of rebate details in range,
+// self selects own trades rebates instead of referral ones
+func (s *Service) WalkRebateDetails(ctx context.Context, r RebateRange, self bool, fn func([]RebateDetail) error) error {
+	query := s.RebateDetail
+	if self {
+		query = s.SelfRebateDetail
+	}
+
+	return walkRebatePages(r, func(req RebateRequest) (int, int, error) {
+		resp, err := query(ctx, req)
+		if err != nil {
+			return 0, 0, err
+		}
+		if len(resp.Data) > 0 {
+			if err := fn(resp.Data); err != nil {
+				return 0, 0, err
+			}
+		}
+		return len(resp.Data), resp.TotalPageNum, nil
+	})
+}
+
+// walkRebatePages requests every page of every window of range, page returns count of records and total page count
+func walkRebatePages(r RebateRange, page func(req RebateRequest) (int, int, error)) error {
+	if !r.End.After(r.Start) {
+		return errors.New("end time must be after start time")
+	}
+
+	for windowStart := r.Start; !windowStart.After(r.End); {
+		windowEnd := windowStart.Add(RebateHistoryWindow)
+		if windowEnd.After(r.End) {
+			windowEnd = r.End
+		}
+
+		for current := 1; ; current++ {
+			from, to, number := windowStart, windowEnd, current
+			count, total, err := page(RebateRequest{StartTime: &from, EndTime: &to, Page: &number, RecvWindow: r.RecvWindow})
+			if err != nil {
+				return err
+			}
+			if count == 0 || current >= total {
+				break
+			}
+		}
+
+		// time bounds are inclusive, next window starts after the end of this one
+		windowStart = windowEnd.Add(time.Millisecond)
+	}
+
+	return nil
+}
+
+func (s *Service) rebateParams(req RebateRequest) map[string]string {
+	params := map[string]string{
+		"timestamp": s.getTimestamp(),
+	}
+	if req.StartTime != nil {
+		params["startTime"] = fmt.Sprintf("%d", req.StartTime.UnixMilli())
+	}
+	if req.EndTime != nil {
+		params["endTime"] = fmt.Sprintf("%d", req.EndTime.UnixMilli())
+	}
+	if req.Page != nil {
+		params["page"] = fmt.Sprintf("%d", *req.Page)
+	}
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+	return params
+}
+
+func (s *Service) sendRebate(ctx context.Context, endpoint string, params map[string]string, resp any) error {
+	body, err := s.client.SendRequest(ctx, http.MethodGet, endpoint, params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, resp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
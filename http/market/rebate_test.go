@@ -0,0 +1,76 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_WalkRebateHistory(t *testing.T) {
+	// two pages in every window
+	s, transport := newFakeService(func(r *http.Request) string {
+		query := r.URL.Query()
+		return fmt.Sprintf(`{"page":%s,"totalPageNum":2,"data":[{"uid":"%s-%s","total":"1"}]}`,
+			query.Get("page"), query.Get("startTime"), query.Get("page"))
+	})
+
+	start := time.UnixMilli(1_700_000_000_000)
+	recvWindow := int64(5000)
+	var uids []string
+	err := s.WalkRebateHistory(context.Background(), RebateRange{
+		Start:      start,
+		End:        start.Add(RebateHistoryWindow + time.Hour),
+		RecvWindow: &recvWindow,
+	}, func(records []RebateHistory) error {
+		for _, r := range records {
+			uids = append(uids, r.UID)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	secondStart := strconv.FormatInt(start.Add(RebateHistoryWindow+time.Millisecond).UnixMilli(), 10)
+	assert.Equal(t, []string{"1700000000000-1", "1700000000000-2", secondStart + "-1", secondStart + "-2"}, uids)
+	require.Len(t, transport.requests, 4)
+	for i := range transport.requests {
+		assert.Equal(t, "/api/v3/rebate/taxQuery", transport.requests[i].URL.Path)
+		assert.Equal(t, "5000", transport.query(i).Get("recvWindow"))
+	}
+	assert.Equal(t, strconv.FormatInt(start.Add(RebateHistoryWindow).UnixMilli(), 10), transport.query(0).Get("endTime"))
+	assert.Equal(t, strconv.FormatInt(start.Add(RebateHistoryWindow+time.Hour).UnixMilli(), 10), transport.query(3).Get("endTime"))
+}
+
+func TestService_WalkRebateDetails(t *testing.T) {
+	s, transport := newFakeService(func(r *http.Request) string {
+		if r.URL.Query().Get("page") == "1" {
+			return `{"page":1,"totalPageNum":3,"data":[{"asset":"USDT","amount":"0.5"}]}`
+		}
+		return `{"page":2,"totalPageNum":3,"data":[]}`
+	})
+
+	start := time.UnixMilli(1_700_000_000_000)
+	recvWindow := int64(5000)
+	var details []RebateDetail
+	err := s.WalkRebateDetails(context.Background(), RebateRange{Start: start, End: start.Add(time.Hour), RecvWindow: &recvWindow}, true,
+		func(records []RebateDetail) error {
+			details = append(details, records...)
+			return nil
+		})
+	require.NoError(t, err)
+
+	// empty page stops paging before total
+	require.Len(t, details, 1)
+	require.Len(t, transport.requests, 2)
+	assert.Equal(t, "/api/v3/rebate/detail/kickback", transport.requests[0].URL.Path)
+	assert.Equal(t, "5000", transport.query(1).Get("recvWindow"))
+
+	err = s.WalkRebateDetails(context.Background(), RebateRange{Start: start, End: start}, false,
+		func([]RebateDetail) error { return nil })
+	require.Error(t, err)
+}
@@ -0,0 +1,135 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+const (
+	replaceConfirmInterval = 200 * time.Millisecond
+	replaceConfirmAttempts = 10
+)
+
+// ReplaceOrderRequest describes cancel-replace of order placed with client order id
+type ReplaceOrderRequest struct {
+	Symbol            string
+	OrigClientOrderId string           // required, order to replace
+	Price             *decimal.Decimal // optional, new price, old price is used if empty, not allowed for market orders
+	Quantity          *decimal.Decimal // optional, new total quantity including already executed one, old quantity is used if empty
+	NewClientOrderId  *string          // optional, ReplacementClientOrderID of OrigClientOrderId if empty
+	RecvWindow        *int64           // optional
+}
+
+// ReplaceOrderResult combines cancelled and new order, NewOrder is nil if nothing is left to place
+type ReplaceOrderResult struct {
+	Cancelled   *GetOrderResponse
+	NewOrder    *CreateOrderResponse
+	ExecutedQty decimal.Decimal // executed quantity of cancelled order
+	NewQty      decimal.Decimal // quantity of new order
+}
+
+// ReplaceOrder emulates amend with cancel and create. Cancelled order is queried until its state is final,
+// so new order is sized by total quantity minus quantity executed before cancellation and never double fills.
+// If old order is filled before cancellation new order is not placed.
+func (s *Service) ReplaceOrder(ctx context.Context, req *ReplaceOrderRequest) (*ReplaceOrderResult, error) {
+	if req.OrigClientOrderId == "" {
+		return nil, errors.New("origClientOrderId is required")
+	}
+
+	newClientOrderID := req.NewClientOrderId
+	if newClientOrderID == nil {
+		id, err := ReplacementClientOrderID(req.OrigClientOrderId)
+		if err != nil {
+			return nil, err
+		}
+		newClientOrderID = &id
+	}
+
+	_, cancelErr := s.CancelOrder(ctx, &CancelOrderRequest{
+		Symbol:            req.Symbol,
+		OrigClientOrderId: &req.OrigClientOrderId,
+		RecvWindow:        req.RecvWindow,
+	})
+
+	old, err := s.confirmCancelled(ctx, req)
+	if err != nil {
+		if cancelErr != nil {
+			return nil, fmt.Errorf("cancel order %s: %w", req.OrigClientOrderId, cancelErr)
+		}
+		return nil, err
+	}
+
+	total := old.OrigQty
+	if req.Quantity != nil {
+		total = *req.Quantity
+	}
+
+	result := &ReplaceOrderResult{
+		Cancelled:   old,
+		ExecutedQty: old.ExecutedQty,
+		NewQty:      decimal.Max(total.Sub(old.ExecutedQty), decimal.Zero),
+	}
+	if old.Status == StatusFilled || !result.NewQty.IsPositive() {
+		result.NewQty = decimal.Zero
+		return result, nil
+	}
+
+	var price *string
+	switch {
+	case old.Type == TypeMarket && req.Price != nil:
+		return result, fmt.Errorf("order %s is cancelled, but market order can't be replaced with price", req.OrigClientOrderId)
+	case old.Type == TypeMarket:
+	case req.Price != nil:
+		price = decimalString(req.Price)
+	default:
+		price = decimalString(&old.Price)
+	}
+
+	result.NewOrder, err = s.CreateOrder(ctx, &CreateOrderRequest{
+		Symbol:           req.Symbol,
+		Side:             old.Side,
+		Type:             old.Type,
+		Quantity:         decimalString(&result.NewQty),
+		Price:            price,
+		NewClientOrderId: newClientOrderID,
+		RecvWindow:       req.RecvWindow,
+	})
+	if err != nil {
+		return result, fmt.Errorf("order %s is cancelled, but new order is not placed: %w", req.OrigClientOrderId, err)
+	}
+
+	return result, nil
+}
+
+// confirmCancelled waits until cancelled order reaches final state with final executed quantity
+func (s *Service) confirmCancelled(ctx context.Context, req *ReplaceOrderRequest) (*GetOrderResponse, error) {
+	var lastErr error
+	for i := 0; i < replaceConfirmAttempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(replaceConfirmInterval):
+			}
+		}
+
+		order, err := s.QueryOrder(ctx, &GetOrderRequest{
+			Symbol:            req.Symbol,
+			OrigClientOrderId: &req.OrigClientOrderId,
+			RecvWindow:        req.RecvWindow,
+		})
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if order.Status.IsFinal() {
+			return order, nil
+		}
+		lastErr = fmt.Errorf("order %s is still %s", req.OrigClientOrderId, order.Status)
+	}
+
+	return nil, fmt.Errorf("confirm cancel of %s: %w", req.OrigClientOrderId, lastErr)
+}
@@ -0,0 +1,101 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// replaceFake answers cancel, query and create of ReplaceOrder, queries return states in order
+func replaceFake(states ...string) func(r *http.Request) string {
+	queries := 0
+	return func(r *http.Request) string {
+		switch r.Method {
+		case http.MethodDelete:
+			return `{"symbol":"BTCUSDT","origClientOrderId":"kt-mm-s-1","status":"CANCELLED"}`
+		case http.MethodGet:
+			state := states[min(queries, len(states)-1)]
+			queries++
+			return state
+		default:
+			return `{"symbol":"BTCUSDT","orderId":"2","clientOrderId":"` + r.URL.Query().Get("newClientOrderId") + `"}`
+		}
+	}
+}
+
+func TestService_ReplaceOrder(t *testing.T) {
+	s, transport := newFakeService(replaceFake(
+		`{"symbol":"BTCUSDT","orderId":"1","status":"PARTIALLY_FILLED","type":"LIMIT","side":"BUY","price":"100","origQty":"1","executedQty":"0.2"}`,
+		`{"symbol":"BTCUSDT","orderId":"1","status":"PARTIALLY_CANCELLED","type":"LIMIT","side":"BUY","price":"100","origQty":"1","executedQty":"0.3"}`,
+	))
+
+	price := decimal.RequireFromString("99.5")
+	res, err := s.ReplaceOrder(context.Background(), &ReplaceOrderRequest{
+		Symbol:            "BTCUSDT",
+		OrigClientOrderId: "kt-mm-s-1",
+		Price:             &price,
+	})
+	require.NoError(t, err)
+
+	// fill landed between cancel and confirmation, new order is sized by the final executed quantity
+	assert.True(t, decimal.RequireFromString("0.3").Equal(res.ExecutedQty))
+	assert.True(t, decimal.RequireFromString("0.7").Equal(res.NewQty))
+	require.NotNil(t, res.NewOrder)
+
+	require.Len(t, transport.requests, 4)
+	create := transport.query(3)
+	assert.Equal(t, http.MethodPost, transport.requests[3].Method)
+	assert.Equal(t, "0.7", create.Get("quantity"))
+	assert.Equal(t, "99.5", create.Get("price"))
+	assert.Equal(t, "kt-mm-s-1_r1", create.Get("newClientOrderId"))
+}
+
+func TestService_ReplaceOrder_Filled(t *testing.T) {
+	s, transport := newFakeService(replaceFake(
+		`{"symbol":"BTCUSDT","orderId":"1","status":"FILLED","type":"LIMIT","side":"BUY","price":"100","origQty":"1","executedQty":"1"}`,
+	))
+
+	qty := decimal.NewFromInt(2)
+	res, err := s.ReplaceOrder(context.Background(), &ReplaceOrderRequest{Symbol: "BTCUSDT", OrigClientOrderId: "kt-mm-s-1", Quantity: &qty})
+	require.NoError(t, err)
+	assert.Nil(t, res.NewOrder)
+	assert.True(t, res.NewQty.IsZero())
+	assert.Len(t, transport.requests, 2, "filled order is not replaced")
+}
+
+func TestService_ReplaceOrder_Market(t *testing.T) {
+	s, transport := newFakeService(replaceFake(
+		`{"symbol":"BTCUSDT","orderId":"1","status":"PARTIALLY_CANCELLED","type":"MARKET","side":"SELL","price":"0","origQty":"1","executedQty":"0.4"}`,
+	))
+
+	res, err := s.ReplaceOrder(context.Background(), &ReplaceOrderRequest{Symbol: "BTCUSDT", OrigClientOrderId: "kt-mm-s-1_r1"})
+	require.NoError(t, err)
+	require.NotNil(t, res.NewOrder)
+
+	create := transport.query(2)
+	assert.False(t, create.Has("price"))
+	assert.Equal(t, "0.6", create.Get("quantity"))
+	assert.Equal(t, "kt-mm-s-1_r2", create.Get("newClientOrderId"))
+}
+
+func TestReplacementClientOrderID(t *testing.T) {
+	id, err := ReplacementClientOrderID("kt-mm-s-1")
+	require.NoError(t, err)
+	assert.Equal(t, "kt-mm-s-1_r1", id)
+
+	parsed, err := ParseClientOrderID("kt-mm-s-a_r3")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), parsed.Sequence)
+	assert.Equal(t, uint64(3), parsed.Replacement)
+
+	id, err = ReplacementClientOrderID("my_rate")
+	require.NoError(t, err)
+	assert.Equal(t, "my_rate_r1", id)
+
+	_, err = ReplacementClientOrderID("0123456789012345678901234567890")
+	assert.Error(t, err)
+}
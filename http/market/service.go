@@ -40,8 +40,18 @@ func (s *Service) syncServerTime(ctx context.Context) error {
 	return nil
 }
 
+// Timestamp returns current server time in milliseconds for signed requests of other services, e.g. sub-account one
+func (s *Service) Timestamp() string {
+	return s.getTimestamp()
+}
+
+// ServerTimeMilli returns current server time in milliseconds, e.g. to compare local events with exchange ones
+func (s *Service) ServerTimeMilli() int64 {
+	return time.Now().UnixMilli() - s.syncTimeDeltaMilliSeconds
+}
+
 func (s *Service) getTimestamp() string {
-	return strconv.FormatInt(time.Now().UnixMilli()-s.syncTimeDeltaMilliSeconds, 10)
+	return strconv.FormatInt(s.ServerTimeMilli(), 10)
 }
 
 // SyncServerTime синхронизирует время сервера.
@@ -0,0 +1,43 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/kattana-io/mexc-golang-sdk/consts"
+	"github.com/shopspring/decimal"
+	"net/http"
+)
+
+// TickerPrice returns last price of symbol or of all symbols if symbol is empty
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#symbol-price-ticker
+func (s *Service) TickerPrice(ctx context.Context, symbol string) ([]*TickerPriceResponse, error) {
+	params := make(map[string]string)
+	if symbol != "" {
+		params["symbol"] = symbol
+	}
+
+	res, err := s.client.SendRequest(ctx, http.MethodGet, consts.EndpointTickerPrice, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if symbol != "" {
+		var price TickerPriceResponse
+		if err := json.Unmarshal(res, &price); err != nil {
+			return nil, err
+		}
+		return []*TickerPriceResponse{&price}, nil
+	}
+
+	var prices []*TickerPriceResponse
+	if err := json.Unmarshal(res, &prices); err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+type TickerPriceResponse struct {
+	Symbol string          `json:"symbol"`
+	Price  decimal.Decimal `json:"price"`
+}
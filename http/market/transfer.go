@@ -0,0 +1,118 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/consts"
+	"net/http"
+	"time"
+)
+
+// TransferRequest moves asset between own accounts, e.g. SPOT and FUTURES
+type TransferRequest struct {
+	FromAccountType AccountType // required
+	ToAccountType   AccountType // required
+	Asset           string      // required
+	Amount          string      // required
+	Symbol          *string     // optional, required for ISOLATED_MARGIN
+	RecvWindow      *int64      // optional
+}
+
+// NewTransfer https://mexcdevelop.github.io/apidocs/spot_v3_en/#user-universal-transfer
+func (s *Service) NewTransfer(ctx context.Context, req TransferRequest) (*TransferResponse, error) {
+	if req.FromAccountType == req.ToAccountType {
+		return nil, errors.New("fromAccountType and toAccountType must differ")
+	}
+
+	params := map[string]string{
+		"fromAccountType": string(req.FromAccountType),
+		"toAccountType":   string(req.ToAccountType),
+		"asset":           req.Asset,
+		"amount":          req.Amount,
+		"timestamp":       s.getTimestamp(),
+	}
+	if req.Symbol != nil {
+		params["symbol"] = *req.Symbol
+	}
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	body, err := s.client.SendRequest(ctx, http.MethodPost, consts.EndpointTransfer, params)
+	if err != nil {
+		return nil, fmt.Errorf("transfer failed: %w", err)
+	}
+
+	var resp TransferResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse transfer response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+type OwnTransferHistoryRequest struct {
+	FromAccountType AccountType // required
+	ToAccountType   AccountType // required
+	StartTime       *time.Time  // optional
+	EndTime         *time.Time  // optional
+	Page            *int        // optional, default 1
+	Size            *int        // optional, default 10, max 100
+	RecvWindow      *int64      // optional
+}
+
+type OwnTransferRecord struct {
+	TranId          string      `json:"tranId"`
+	ClientTranId    string      `json:"clientTranId"`
+	Asset           string      `json:"asset"`
+	Amount          string      `json:"amount"`
+	FromAccountType AccountType `json:"fromAccountType"`
+	ToAccountType   AccountType `json:"toAccountType"`
+	FromSymbol      string      `json:"fromSymbol"`
+	ToSymbol        string      `json:"toSymbol"`
+	Status          string      `json:"status"`
+	Timestamp       int64       `json:"timestamp"`
+}
+
+type OwnTransferHistoryResponse struct {
+	Rows  []OwnTransferRecord `json:"rows"`
+	Total int32               `json:"total"`
+}
+
+// GetTransferHistory https://mexcdevelop.github.io/apidocs/spot_v3_en/#query-user-universal-transfer-history
+func (s *Service) GetTransferHistory(ctx context.Context, req OwnTransferHistoryRequest) (*OwnTransferHistoryResponse, error) {
+	params := map[string]string{
+		"fromAccountType": string(req.FromAccountType),
+		"toAccountType":   string(req.ToAccountType),
+		"timestamp":       s.getTimestamp(),
+	}
+	if req.StartTime != nil {
+		params["startTime"] = fmt.Sprintf("%d", req.StartTime.UnixMilli())
+	}
+	if req.EndTime != nil {
+		params["endTime"] = fmt.Sprintf("%d", req.EndTime.UnixMilli())
+	}
+	if req.Page != nil {
+		params["page"] = fmt.Sprintf("%d", *req.Page)
+	}
+	if req.Size != nil {
+		params["size"] = fmt.Sprintf("%d", *req.Size)
+	}
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	body, err := s.client.SendRequest(ctx, http.MethodGet, consts.EndpointTransfer, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transfer history: %w", err)
+	}
+
+	var resp OwnTransferHistoryResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse transfer history: %w", err)
+	}
+
+	return &resp, nil
+}
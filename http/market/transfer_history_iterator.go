@@ -0,0 +1,112 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	MaxTransferHistoryLimit = 100
+	TransferHistoryWindow   = 7 * 24 * time.Hour // max time range of a single transfer history call
+)
+
+// TransferHistoryRange selects transfers between Start and End, account fields are used by universal transfers only
+type TransferHistoryRange struct {
+	Start           time.Time   // required
+	End             time.Time   // required
+	FromAccount     *string     // optional
+	ToAccount       *string     // optional
+	FromAccountType AccountType // required for universal transfers
+	ToAccountType   AccountType // required for universal transfers
+	RecvWindow      *int64      // optional
+}
+
+// WalkInternalTransfers calls fn with every page of internal transfers in range,
+// range is split into TransferHistoryWindow windows
+func (s *Service) WalkInternalTransfers(ctx context.Context, r TransferHistoryRange,
+	fn func([]InternalTransferRecord) error) error {
+	return walkTransferWindows(r, func(start, end time.Time) error {
+		limit := MaxTransferHistoryLimit
+		for page := 1; ; page++ {
+			page := page
+			resp, err := s.GetInternalTransferHistory(ctx, InternalTransferHistoryRequest{
+				StartTime:  &start,
+				EndTime:    &end,
+				Page:       &page,
+				Limit:      &limit,
+				RecvWindow: r.RecvWindow,
+			})
+			if err != nil {
+				return fmt.Errorf("internal transfers page %d from %s: %w", page, start, err)
+			}
+
+			if len(resp.Data) > 0 {
+				if err := fn(resp.Data); err != nil {
+					return err
+				}
+			}
+			if len(resp.Data) == 0 || page >= resp.TotalPageNum {
+				return nil
+			}
+		}
+	})
+}
+
+// WalkUniversalTransfers calls fn with every page of universal transfers in range,
+// range is split into TransferHistoryWindow windows
+func (s *Service) WalkUniversalTransfers(ctx context.Context, r TransferHistoryRange,
+	fn func([]TransferRecord) error) error {
+	return walkTransferWindows(r, func(start, end time.Time) error {
+		limit := MaxTransferHistoryLimit
+		seen := 0
+		for page := 1; ; page++ {
+			page := page
+			resp, err := s.GetUniversalTransferHistory(ctx, TransferHistoryRequest{
+				FromAccount:     r.FromAccount,
+				ToAccount:       r.ToAccount,
+				FromAccountType: r.FromAccountType,
+				ToAccountType:   r.ToAccountType,
+				StartTime:       &start,
+				EndTime:         &end,
+				Page:            &page,
+				Limit:           &limit,
+				RecvWindow:      r.RecvWindow,
+			})
+			if err != nil {
+				return fmt.Errorf("universal transfers page %d from %s: %w", page, start, err)
+			}
+
+			if len(resp.Result) > 0 {
+				if err := fn(resp.Result); err != nil {
+					return err
+				}
+			}
+			seen += len(resp.Result)
+			if len(resp.Result) == 0 || seen >= int(resp.TotalCount) {
+				return nil
+			}
+		}
+	})
+}
+
+func walkTransferWindows(r TransferHistoryRange, fn func(start, end time.Time) error) error {
+	if !r.End.After(r.Start) {
+		return errors.New("end time must be after start time")
+	}
+
+	for start := r.Start; !start.After(r.End); {
+		end := start.Add(TransferHistoryWindow)
+		if end.After(r.End) {
+			end = r.End
+		}
+		if err := fn(start, end); err != nil {
+			return err
+		}
+		// time bounds are inclusive, next window starts after the end of this one
+		start = end.Add(time.Millisecond)
+	}
+
+	return nil
+}
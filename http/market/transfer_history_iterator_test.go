@@ -0,0 +1,201 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkTransferWindows(t *testing.T) {
+	start := time.UnixMilli(1_700_000_000_000)
+	ms := time.Millisecond
+
+	tests := []struct {
+		name    string
+		end     time.Time
+		windows [][2]time.Time
+		wantErr bool
+	}{
+		{name: "empty range", end: start, wantErr: true},
+		{name: "reversed range", end: start.Add(-ms), wantErr: true},
+		{name: "shorter than window", end: start.Add(time.Hour), windows: [][2]time.Time{{start, start.Add(time.Hour)}}},
+		{
+			name:    "exactly one window",
+			end:     start.Add(TransferHistoryWindow),
+			windows: [][2]time.Time{{start, start.Add(TransferHistoryWindow)}},
+		},
+		{
+			name: "one millisecond over window",
+			end:  start.Add(TransferHistoryWindow + ms),
+			windows: [][2]time.Time{
+				{start, start.Add(TransferHistoryWindow)},
+				{start.Add(TransferHistoryWindow + ms), start.Add(TransferHistoryWindow + ms)},
+			},
+		},
+		{
+			name: "two and a half windows",
+			end:  start.Add(TransferHistoryWindow*5/2 + 2*ms),
+			windows: [][2]time.Time{
+				{start, start.Add(TransferHistoryWindow)},
+				{start.Add(TransferHistoryWindow + ms), start.Add(2*TransferHistoryWindow + ms)},
+				{start.Add(2*TransferHistoryWindow + 2*ms), start.Add(TransferHistoryWindow*5/2 + 2*ms)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var windows [][2]time.Time
+			err := walkTransferWindows(TransferHistoryRange{Start: start, End: tt.end}, func(from, to time.Time) error {
+				windows = append(windows, [2]time.Time{from, to})
+				return nil
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Empty(t, windows)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.windows, windows)
+		})
+	}
+}
+
+func TestService_WalkInternalTransfers(t *testing.T) {
+	tests := []struct {
+		name     string
+		pages    [][]string // transfer ids of page
+		total    int        // totalPageNum
+		requests int
+	}{
+		{name: "no transfers", pages: [][]string{{}}, total: 0, requests: 1},
+		{name: "single page", pages: [][]string{{"1", "2"}}, total: 1, requests: 1},
+		{name: "all pages", pages: [][]string{{"1"}, {"2"}, {"3"}}, total: 3, requests: 3},
+		{name: "empty page before total", pages: [][]string{{"1"}, {}}, total: 5, requests: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, transport := newFakeService(func(r *http.Request) string {
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				var rows []string
+				if page <= len(tt.pages) {
+					for _, id := range tt.pages[page-1] {
+						rows = append(rows, fmt.Sprintf(`{"tranId":%q}`, id))
+					}
+				}
+				return fmt.Sprintf(`{"page":%d,"totalPageNum":%d,"data":[%s]}`, page, tt.total, strings.Join(rows, ","))
+			})
+
+			recvWindow := int64(5000)
+			start := time.UnixMilli(1_700_000_000_000)
+			var ids []string
+			err := s.WalkInternalTransfers(context.Background(),
+				TransferHistoryRange{Start: start, End: start.Add(time.Hour), RecvWindow: &recvWindow},
+				func(records []InternalTransferRecord) error {
+					for _, r := range records {
+						ids = append(ids, r.TranId)
+					}
+					return nil
+				})
+			require.NoError(t, err)
+
+			var want []string
+			for _, page := range tt.pages {
+				want = append(want, page...)
+			}
+			assert.Equal(t, want, ids)
+			require.Len(t, transport.requests, tt.requests)
+			for i := range transport.requests {
+				assert.Equal(t, "/api/v3/capital/transfer/internal", transport.requests[i].URL.Path)
+				assert.Equal(t, strconv.Itoa(i+1), transport.query(i).Get("page"))
+				assert.Equal(t, "100", transport.query(i).Get("limit"))
+				assert.Equal(t, "5000", transport.query(i).Get("recvWindow"))
+				assert.Equal(t, "1700000000000", transport.query(i).Get("startTime"))
+				assert.Equal(t, "1700003600000", transport.query(i).Get("endTime"))
+			}
+		})
+	}
+}
+
+func TestService_WalkUniversalTransfers(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int // totalCount
+		served   int // records server has, may be less than total
+		requests int
+	}{
+		{name: "no transfers", total: 0, served: 0, requests: 1},
+		{name: "single partial page", total: 30, served: 30, requests: 1},
+		{name: "exactly full page", total: 100, served: 100, requests: 1},
+		{name: "last partial page", total: 250, served: 250, requests: 3},
+		{name: "fewer records than total", total: 250, served: 150, requests: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, transport := newFakeService(func(r *http.Request) string {
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+				var rows []string
+				for i := (page - 1) * limit; i < min(page*limit, tt.served); i++ {
+					rows = append(rows, fmt.Sprintf(`{"tranId":"%d"}`, i))
+				}
+				return fmt.Sprintf(`{"totalCount":%d,"result":[%s]}`, tt.total, strings.Join(rows, ","))
+			})
+
+			recvWindow := int64(5000)
+			start := time.UnixMilli(1_700_000_000_000)
+			received := 0
+			err := s.WalkUniversalTransfers(context.Background(), TransferHistoryRange{
+				Start:           start,
+				End:             start.Add(time.Hour),
+				FromAccountType: AccountTypeSpot,
+				ToAccountType:   AccountTypeFutures,
+				RecvWindow:      &recvWindow,
+			}, func(records []TransferRecord) error {
+				received += len(records)
+				return nil
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.served, received)
+			require.Len(t, transport.requests, tt.requests)
+			for i := range transport.requests {
+				assert.Equal(t, "/api/v3/capital/sub-account/universalTransfer", transport.requests[i].URL.Path)
+				assert.Equal(t, "SPOT", transport.query(i).Get("fromAccountType"))
+				assert.Equal(t, "FUTURES", transport.query(i).Get("toAccountType"))
+				assert.Equal(t, "5000", transport.query(i).Get("recvWindow"))
+			}
+		})
+	}
+}
+
+func TestService_NewUniversalTransfer_RecvWindow(t *testing.T) {
+	s, transport := newFakeService(func(*http.Request) string { return `{"tranId":"t1"}` })
+
+	toAccount := "sub1@example.com"
+	recvWindow := int64(60000)
+	resp, err := s.NewUniversalTransfer(context.Background(), UniversalTransferRequest{
+		ToAccount:       &toAccount,
+		FromAccountType: AccountTypeSpot,
+		ToAccountType:   AccountTypeSpot,
+		Asset:           "USDT",
+		Amount:          "10",
+		RecvWindow:      &recvWindow,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "t1", resp.TranId)
+
+	require.Len(t, transport.requests, 1)
+	assert.Equal(t, http.MethodPost, transport.requests[0].Method)
+	assert.Equal(t, "60000", transport.query(0).Get("recvWindow"))
+	assert.Equal(t, toAccount, transport.query(0).Get("toAccount"))
+}
@@ -0,0 +1,86 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_NewTransfer(t *testing.T) {
+	s, transport := newFakeService(func(*http.Request) string { return `{"tranId":"t1"}` })
+	ctx := context.Background()
+
+	_, err := s.NewTransfer(ctx, TransferRequest{
+		FromAccountType: AccountTypeSpot,
+		ToAccountType:   AccountTypeSpot,
+		Asset:           "USDT",
+		Amount:          "10",
+	})
+	require.Error(t, err)
+	assert.Empty(t, transport.requests)
+
+	symbol := "BTCUSDT"
+	recvWindow := int64(5000)
+	resp, err := s.NewTransfer(ctx, TransferRequest{
+		FromAccountType: AccountTypeSpot,
+		ToAccountType:   AccountTypeIsolatedMargin,
+		Asset:           "USDT",
+		Amount:          "10.5",
+		Symbol:          &symbol,
+		RecvWindow:      &recvWindow,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "t1", resp.TranId)
+
+	require.Len(t, transport.requests, 1)
+	assert.Equal(t, http.MethodPost, transport.requests[0].Method)
+	assert.Equal(t, "/api/v3/capital/transfer", transport.requests[0].URL.Path)
+	query := transport.query(0)
+	assert.Equal(t, "SPOT", query.Get("fromAccountType"))
+	assert.Equal(t, "ISOLATED_MARGIN", query.Get("toAccountType"))
+	assert.Equal(t, "USDT", query.Get("asset"))
+	assert.Equal(t, "10.5", query.Get("amount"))
+	assert.Equal(t, "BTCUSDT", query.Get("symbol"))
+	assert.Equal(t, "5000", query.Get("recvWindow"))
+	assert.NotEmpty(t, query.Get("timestamp"))
+}
+
+func TestService_GetTransferHistory(t *testing.T) {
+	s, transport := newFakeService(func(*http.Request) string {
+		return `{"rows":[{"tranId":"t1","asset":"USDT","amount":"20","fromAccountType":"SPOT","toAccountType":"FUTURES",
+			"status":"SUCCESS","timestamp":1700000000000}],"total":1}`
+	})
+
+	start := time.UnixMilli(1_700_000_000_000)
+	end := start.Add(time.Hour)
+	page, size := 2, 50
+	recvWindow := int64(5000)
+	resp, err := s.GetTransferHistory(context.Background(), OwnTransferHistoryRequest{
+		FromAccountType: AccountTypeSpot,
+		ToAccountType:   AccountTypeFutures,
+		StartTime:       &start,
+		EndTime:         &end,
+		Page:            &page,
+		Size:            &size,
+		RecvWindow:      &recvWindow,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Rows, 1)
+	assert.Equal(t, AccountTypeFutures, resp.Rows[0].ToAccountType)
+	assert.Equal(t, int32(1), resp.Total)
+
+	assert.Equal(t, http.MethodGet, transport.requests[0].Method)
+	assert.Equal(t, "/api/v3/capital/transfer", transport.requests[0].URL.Path)
+	query := transport.query(0)
+	assert.Equal(t, "SPOT", query.Get("fromAccountType"))
+	assert.Equal(t, "FUTURES", query.Get("toAccountType"))
+	assert.Equal(t, "1700000000000", query.Get("startTime"))
+	assert.Equal(t, "1700003600000", query.Get("endTime"))
+	assert.Equal(t, "2", query.Get("page"))
+	assert.Equal(t, "50", query.Get("size"))
+	assert.Equal(t, "5000", query.Get("recvWindow"))
+}
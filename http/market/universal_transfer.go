@@ -6,16 +6,17 @@ import (
 	"fmt"
 	"github.com/kattana-io/mexc-golang-sdk/consts"
 	"net/http"
+	"time"
 )
 
 type UniversalTransferRequest struct {
-	FromAccount     *string // optional, the sender’s identifier (email, UID or mobile)
-	ToAccount       *string // optional, the recipient’s identifier (email, UID or mobile)
-	FromAccountType string  // required, e.g. "SPOT" / "FUTURES"
-	ToAccountType   string  // required, e.g. "SPOT" / "FUTURES"
-	Asset           string  // required, e.g. “BNB”, “USDT”, “BTC” — must be a supported token
-	Amount          string  // required, decimal string, e.g. “0.002” — ≤ available balance, respects on-chain precision
-	RecvWindow      *int64  // optional, request validity window in ms (default 5000)
+	FromAccount     *string     // optional, the sender’s identifier (email, UID or mobile)
+	ToAccount       *string     // optional, the recipient’s identifier (email, UID or mobile)
+	FromAccountType AccountType // required
+	ToAccountType   AccountType // required
+	Asset           string      // required, e.g. “BNB”, “USDT”, “BTC” — must be a supported token
+	Amount          string      // required, decimal string, e.g. “0.002” — ≤ available balance, respects on-chain precision
+	RecvWindow      *int64      // optional, request validity window in ms (default 5000)
 }
 
 type TransferResponse struct {
@@ -31,8 +32,8 @@ func (s *Service) NewUniversalTransfer(ctx context.Context, req UniversalTransfe
 	params := map[string]string{
 		"asset":           req.Asset,
 		"amount":          req.Amount,
-		"fromAccountType": req.FromAccountType,
-		"toAccountType":   req.ToAccountType,
+		"fromAccountType": string(req.FromAccountType),
+		"toAccountType":   string(req.ToAccountType),
 		"timestamp":       s.getTimestamp(),
 	}
 
@@ -43,7 +44,7 @@ func (s *Service) NewUniversalTransfer(ctx context.Context, req UniversalTransfe
 		params["toAccount"] = *req.ToAccount
 	}
 	if req.RecvWindow != nil {
-		params["recvWindow"] = fmt.Sprintf("%d", req.RecvWindow)
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
 	}
 
 	body, err := s.client.SendRequest(ctx, http.MethodPost, consts.EndpointUniversalTransfer, params)
@@ -60,29 +61,29 @@ func (s *Service) NewUniversalTransfer(ctx context.Context, req UniversalTransfe
 }
 
 type TransferHistoryRequest struct {
-	FromAccount     *string // optional
-	ToAccount       *string // optional
-	FromAccountType string  // required
-	ToAccountType   string  // required
-	StartTime       *string // optional
-	EndTime         *string // optional
-	Page            *string // optional
-	Limit           *string // optional
-	RecvWindow      *int64  // optional
+	FromAccount     *string     // optional
+	ToAccount       *string     // optional
+	FromAccountType AccountType // required
+	ToAccountType   AccountType // required
+	StartTime       *time.Time  // optional
+	EndTime         *time.Time  // optional
+	Page            *int        // optional, default 1
+	Limit           *int        // optional, default 10, max 100
+	RecvWindow      *int64      // optional
 }
 
 type TransferRecord struct {
-	TranId          string `json:"tranId"`
-	FromAccount     string `json:"fromAccount"`
-	ToAccount       string `json:"toAccount"`
-	ClientTranId    string `json:"clientTranId"`
-	Asset           string `json:"asset"`
-	Amount          string `json:"amount"`
-	FromAccountType string `json:"fromAccountType"`
-	ToAccountType   string `json:"toAccountType"`
-	Symbol          string `json:"symbol"`
-	Status          string `json:"status"`
-	Timestamp       int64  `json:"timestamp"`
+	TranId          string      `json:"tranId"`
+	FromAccount     string      `json:"fromAccount"`
+	ToAccount       string      `json:"toAccount"`
+	ClientTranId    string      `json:"clientTranId"`
+	Asset           string      `json:"asset"`
+	Amount          string      `json:"amount"`
+	FromAccountType AccountType `json:"fromAccountType"`
+	ToAccountType   AccountType `json:"toAccountType"`
+	Symbol          string      `json:"symbol"`
+	Status          string      `json:"status"`
+	Timestamp       int64       `json:"timestamp"`
 }
 
 type TransferHistoryResponse struct {
@@ -93,28 +94,28 @@ type TransferHistoryResponse struct {
 func (s *Service) GetUniversalTransferHistory(ctx context.Context, req TransferHistoryRequest) (*TransferHistoryResponse, error) {
 	// https://mexcdevelop.github.io/apidocs/spot_v3_en/#query-universal-transfer-history-for-master-account
 	params := map[string]string{
-		"fromAccountType": req.FromAccountType,
-		"toAccountType":   req.ToAccountType,
+		"fromAccountType": string(req.FromAccountType),
+		"toAccountType":   string(req.ToAccountType),
 		"timestamp":       s.getTimestamp(),
 	}
 
 	if req.FromAccount != nil {
-		params["fromAccount"] = fmt.Sprintf("%s", *req.FromAccount)
+		params["fromAccount"] = *req.FromAccount
 	}
 	if req.ToAccount != nil {
-		params["toAccount"] = fmt.Sprintf("%s", *req.ToAccount)
+		params["toAccount"] = *req.ToAccount
 	}
 	if req.StartTime != nil {
-		params["startTime"] = fmt.Sprintf("%s", *req.StartTime)
+		params["startTime"] = fmt.Sprintf("%d", req.StartTime.UnixMilli())
 	}
 	if req.EndTime != nil {
-		params["endTime"] = fmt.Sprintf("%s", *req.EndTime)
+		params["endTime"] = fmt.Sprintf("%d", req.EndTime.UnixMilli())
 	}
 	if req.Page != nil {
-		params["page"] = fmt.Sprintf("%s", *req.Page)
+		params["page"] = fmt.Sprintf("%d", *req.Page)
 	}
 	if req.Limit != nil {
-		params["limit"] = fmt.Sprintf("%s", *req.Limit)
+		params["limit"] = fmt.Sprintf("%d", *req.Limit)
 	}
 	if req.RecvWindow != nil {
 		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
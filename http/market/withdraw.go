@@ -3,9 +3,11 @@ package mexchttpmarket
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/kattana-io/mexc-golang-sdk/consts"
 	"net/http"
+	"time"
 )
 
 type WithdrawRequest struct {
@@ -131,18 +133,112 @@ func (s *Service) GetWithdrawsHistory(ctx context.Context, req WithdrawHistoryRe
 	return withdraws, nil
 }
 
+// GetWithdrawHistoryByID searches withdrawals applied within the last WithdrawHistoryWindow
 func (s *Service) GetWithdrawHistoryByID(ctx context.Context, withdrawId string) (*WithdrawRecord, error) {
-	req := WithdrawHistoryRequest{}
-	withdraws, err := s.GetWithdrawsHistory(ctx, req)
+	now := time.Now()
+
+	var found *WithdrawRecord
+	err := s.WalkWithdrawHistory(ctx, WithdrawHistoryRange{Start: now.Add(-WithdrawHistoryWindow), End: now},
+		func(records []WithdrawRecord) error {
+			for i := range records {
+				if records[i].ID == withdrawId {
+					found = &records[i]
+					return errStopWalk
+				}
+			}
+			return nil
+		})
+	if err != nil && !errors.Is(err, errStopWalk) {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("withdraw record with id %s not found", withdrawId)
+	}
+
+	return found, nil
+}
+
+type CancelWithdrawRequest struct {
+	ID         string // required
+	RecvWindow *int64 // optional
+}
+
+type CancelWithdrawResponse struct {
+	ID string `json:"id"`
+}
+
+// CancelWithdraw https://mexcdevelop.github.io/apidocs/spot_v3_en/#cancel-withdraw
+func (s *Service) CancelWithdraw(ctx context.Context, req CancelWithdrawRequest) (*CancelWithdrawResponse, error) {
+	params := map[string]string{
+		"id":        req.ID,
+		"timestamp": s.getTimestamp(),
+	}
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	body, err := s.client.SendRequest(ctx, http.MethodDelete, consts.EndpointWithdraw, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp CancelWithdrawResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse cancel withdraw response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+type WithdrawAddressRequest struct {
+	Coin       *string // optional
+	Page       *int32  // optional, default 1
+	Limit      *int32  // optional, default 20
+	RecvWindow *int64  // optional
+}
+
+type WithdrawAddressResponse struct {
+	Data         []WithdrawAddress `json:"data"`
+	TotalRecords int32             `json:"totalRecords"`
+	Page         int32             `json:"page"`
+	TotalPageNum int32             `json:"totalPageNum"`
+}
+
+type WithdrawAddress struct {
+	Coin       string `json:"coin"`
+	Network    string `json:"network"`
+	Address    string `json:"address"`
+	AddressTag string `json:"addressTag"`
+	Memo       string `json:"memo"`
+}
+
+// GetWithdrawAddresses returns address book https://mexcdevelop.github.io/apidocs/spot_v3_en/#withdraw-address-supporting-network
+func (s *Service) GetWithdrawAddresses(ctx context.Context, req WithdrawAddressRequest) (*WithdrawAddressResponse, error) {
+	params := map[string]string{
+		"timestamp": s.getTimestamp(),
+	}
+	if req.Coin != nil {
+		params["coin"] = *req.Coin
+	}
+	if req.Page != nil {
+		params["page"] = fmt.Sprintf("%d", *req.Page)
+	}
+	if req.Limit != nil {
+		params["limit"] = fmt.Sprintf("%d", *req.Limit)
+	}
+	if req.RecvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *req.RecvWindow)
+	}
+
+	body, err := s.client.SendRequest(ctx, http.MethodGet, consts.EndpointWithdrawAddress, params)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, w := range withdraws {
-		if w.ID == withdrawId {
-			return &w, nil
-		}
+	var resp WithdrawAddressResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse withdraw addresses: %w", err)
 	}
 
-	return nil, fmt.Errorf("withdraw record with id %s not found", withdrawId)
+	return &resp, nil
 }
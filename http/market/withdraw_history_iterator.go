@@ -0,0 +1,88 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	MaxWithdrawHistoryLimit = 1000
+	WithdrawHistoryWindow   = 90 * 24 * time.Hour // max time range of a single withdraw history call
+)
+
+// WithdrawHistoryRange selects withdrawals applied between Start and End
+type WithdrawHistoryRange struct {
+	Coin       *string // optional
+	Start      time.Time
+	End        time.Time
+	RecvWindow *int64 // optional
+}
+
+// errStopWalk stops walking early without error
+var errStopWalk = errors.New("stop walk")
+
+// WalkWithdrawHistory calls fn with every page of withdrawals in range. Range is split into WithdrawHistoryWindow
+// windows, a full page is continued with end time moved to the oldest apply time of the page,
+// records repeated at page boundaries are skipped.
+func (s *Service) WalkWithdrawHistory(ctx context.Context, r WithdrawHistoryRange, fn func([]WithdrawRecord) error) error {
+	if !r.End.After(r.Start) {
+		return errors.New("end time must be after start time")
+	}
+
+	limit := strconv.Itoa(MaxWithdrawHistoryLimit)
+	seen := make(map[string]struct{})
+	for windowStart := r.Start.UnixMilli(); windowStart <= r.End.UnixMilli(); {
+		windowEnd := min(windowStart+WithdrawHistoryWindow.Milliseconds(), r.End.UnixMilli())
+
+		to := windowEnd
+		for {
+			startTime, endTime := strconv.FormatInt(windowStart, 10), strconv.FormatInt(to, 10)
+			records, err := s.GetWithdrawsHistory(ctx, WithdrawHistoryRequest{
+				Coin:       r.Coin,
+				StartTime:  &startTime,
+				EndTime:    &endTime,
+				Limit:      &limit,
+				RecvWindow: r.RecvWindow,
+			})
+			if err != nil {
+				return fmt.Errorf("withdraw history to %d: %w", to, err)
+			}
+
+			page := make([]WithdrawRecord, 0, len(records))
+			oldest := to
+			for _, record := range records {
+				if _, ok := seen[record.ID]; !ok {
+					seen[record.ID] = struct{}{}
+					page = append(page, record)
+				}
+				oldest = min(oldest, record.ApplyTime)
+			}
+
+			if len(page) > 0 {
+				if err := fn(page); err != nil {
+					return err
+				}
+			}
+
+			if len(records) < MaxWithdrawHistoryLimit {
+				break
+			}
+			if len(page) == 0 {
+				// the whole page is the same millisecond, continue before it
+				oldest--
+			}
+			if oldest < windowStart {
+				break
+			}
+			to = oldest
+		}
+
+		// time bounds are inclusive, next window starts after the end of this one
+		windowStart = windowEnd + 1
+	}
+
+	return nil
+}
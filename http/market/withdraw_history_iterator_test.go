@@ -0,0 +1,71 @@
+package mexchttpmarket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withdrawFake serves withdraw history newest first with inclusive time bounds and limit
+func withdrawFake(t *testing.T, records []WithdrawRecord) func(r *http.Request) string {
+	sort.SliceStable(records, func(i, j int) bool { return records[i].ApplyTime > records[j].ApplyTime })
+
+	return func(r *http.Request) string {
+		q := r.URL.Query()
+		start, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		require.LessOrEqual(t, end-start, WithdrawHistoryWindow.Milliseconds())
+
+		page := make([]WithdrawRecord, 0, limit)
+		for _, record := range records {
+			if record.ApplyTime >= start && record.ApplyTime <= end && len(page) < limit {
+				page = append(page, record)
+			}
+		}
+
+		body, err := json.Marshal(page)
+		require.NoError(t, err)
+		return string(body)
+	}
+}
+
+func TestService_WalkWithdrawHistory(t *testing.T) {
+	start := time.UnixMilli(1_700_000_000_000)
+	window := WithdrawHistoryWindow.Milliseconds()
+
+	var records []WithdrawRecord
+	// more records than a page holds, two of them share a millisecond at page boundary
+	for i := range MaxWithdrawHistoryLimit + 300 {
+		records = append(records, WithdrawRecord{ID: strconv.Itoa(i), ApplyTime: start.UnixMilli() + int64(i/2)})
+	}
+	records = append(records,
+		WithdrawRecord{ID: "boundary", ApplyTime: start.UnixMilli() + window},
+		WithdrawRecord{ID: "second-window", ApplyTime: start.UnixMilli() + window + 10},
+	)
+
+	s, transport := newFakeService(withdrawFake(t, records))
+
+	counts := make(map[string]int)
+	err := s.WalkWithdrawHistory(context.Background(), WithdrawHistoryRange{Start: start, End: start.Add(2 * WithdrawHistoryWindow)},
+		func(page []WithdrawRecord) error {
+			for _, record := range page {
+				counts[record.ID]++
+			}
+			return nil
+		})
+	require.NoError(t, err)
+
+	assert.Len(t, counts, len(records))
+	for id, n := range counts {
+		assert.Equal(t, 1, n, id)
+	}
+	assert.Greater(t, len(transport.requests), 2, "full page is continued")
+}
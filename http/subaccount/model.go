@@ -0,0 +1,95 @@
+package mexchttpsubaccount
+
+import (
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+)
+
+type Permission string
+
+const (
+	PermissionSpotAccountRead      Permission = "SPOT_ACCOUNT_READ"
+	PermissionSpotAccountWrite     Permission = "SPOT_ACCOUNT_WRITE"
+	PermissionSpotDealRead         Permission = "SPOT_DEAL_READ"
+	PermissionSpotDealWrite        Permission = "SPOT_DEAL_WRITE"
+	PermissionContractAccountRead  Permission = "CONTRACT_ACCOUNT_READ"
+	PermissionContractAccountWrite Permission = "CONTRACT_ACCOUNT_WRITE"
+	PermissionContractDealRead     Permission = "CONTRACT_DEAL_READ"
+	PermissionContractDealWrite    Permission = "CONTRACT_DEAL_WRITE"
+	PermissionSpotTransferRead     Permission = "SPOT_TRANSFER_READ"
+	PermissionSpotTransferWrite    Permission = "SPOT_TRANSFER_WRITE"
+)
+
+type CreateSubAccountRequest struct {
+	SubAccount string // required, sub-account name, 8-32 letters and numbers
+	Note       string // required
+	RecvWindow *int64 // optional
+}
+
+type SubAccount struct {
+	SubAccount string `json:"subAccount"`
+	Note       string `json:"note"`
+	IsFreeze   bool   `json:"isFreeze"`
+	CreateTime int64  `json:"createTime"`
+	UID        string `json:"uid"`
+}
+
+type ListSubAccountsRequest struct {
+	SubAccount *string // optional
+	IsFreeze   *bool   // optional
+	Page       *int32  // optional, default 1
+	Limit      *int32  // optional, default 10, max 200
+	RecvWindow *int64  // optional
+}
+
+type ListSubAccountsResponse struct {
+	SubAccounts []SubAccount `json:"subAccounts"`
+}
+
+type CreateAPIKeyRequest struct {
+	SubAccount  string       // required
+	Note        string       // required
+	Permissions []Permission // required
+	IPs         []string     // optional, up to 20 bound IPs
+	RecvWindow  *int64       // optional
+}
+
+type APIKey struct {
+	SubAccount  string `json:"subAccount,omitempty"`
+	Note        string `json:"note"`
+	APIKey      string `json:"apiKey"`
+	SecretKey   string `json:"secretKey,omitempty"` // returned on creation only
+	Permissions string `json:"permissions"`
+	IP          string `json:"ip"`
+	CreateTime  int64  `json:"creatTime"`
+}
+
+type ListAPIKeysResponse struct {
+	SubAccount []APIKey `json:"subAccount"`
+}
+
+type DeleteAPIKeyRequest struct {
+	SubAccount string // required
+	APIKey     string // required
+	RecvWindow *int64 // optional
+}
+
+type DeleteAPIKeyResponse struct {
+	SubAccount string `json:"subAccount"`
+}
+
+type AssetRequest struct {
+	SubAccount  string                     // required
+	AccountType mexchttpmarket.AccountType // required, SPOT or FUTURES
+	RecvWindow  *int64                     // optional
+}
+
+type AssetResponse struct {
+	Balances []Balance `json:"balances"`
+}
+
+type Balance struct {
+	Asset  string          `json:"asset"`
+	Free   decimal.Decimal `json:"free"`
+	Locked decimal.Decimal `json:"locked"`
+}
@@ -0,0 +1,165 @@
+package mexchttpsubaccount
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/consts"
+	mexchttp "github.com/kattana-io/mexc-golang-sdk/http"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Service implements sub-account apis of master account https://mexcdevelop.github.io/apidocs/spot_v3_en/#sub-account-endpoints
+type Service struct {
+	client    *mexchttp.Client
+	timestamp func() string
+}
+
+// New timestamp returns server-synced time in milliseconds, e.g. mexchttpmarket.Service.Timestamp, local time if nil
+func New(client *mexchttp.Client, timestamp func() string) *Service {
+	if timestamp == nil {
+		timestamp = func() string {
+			return strconv.FormatInt(time.Now().UnixMilli(), 10)
+		}
+	}
+
+	return &Service{
+		client:    client,
+		timestamp: timestamp,
+	}
+}
+
+// CreateSubAccount creates virtual sub-account
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#create-a-sub-account-for-master-account
+func (s *Service) CreateSubAccount(ctx context.Context, req CreateSubAccountRequest) (*SubAccount, error) {
+	params := s.params(req.RecvWindow)
+	params["subAccount"] = req.SubAccount
+	params["note"] = req.Note
+
+	var resp SubAccount
+	if err := s.send(ctx, http.MethodPost, consts.EndpointSubAccountVirtual, params, &resp); err != nil {
+		return nil, fmt.Errorf("create sub-account: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// ListSubAccounts https://mexcdevelop.github.io/apidocs/spot_v3_en/#query-sub-account-list-for-master-account
+func (s *Service) ListSubAccounts(ctx context.Context, req ListSubAccountsRequest) (*ListSubAccountsResponse, error) {
+	params := s.params(req.RecvWindow)
+	if req.SubAccount != nil {
+		params["subAccount"] = *req.SubAccount
+	}
+	if req.IsFreeze != nil {
+		params["isFreeze"] = strconv.FormatBool(*req.IsFreeze)
+	}
+	if req.Page != nil {
+		params["page"] = fmt.Sprintf("%d", *req.Page)
+	}
+	if req.Limit != nil {
+		params["limit"] = fmt.Sprintf("%d", *req.Limit)
+	}
+
+	var resp ListSubAccountsResponse
+	if err := s.send(ctx, http.MethodGet, consts.EndpointSubAccountList, params, &resp); err != nil {
+		return nil, fmt.Errorf("list sub-accounts: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// CreateAPIKey https://mexcdevelop.github.io/apidocs/spot_v3_en/#create-an-apikey-for-a-sub-account-for-master-account
+func (s *Service) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*APIKey, error) {
+	if len(req.Permissions) == 0 {
+		return nil, errors.New("at least one permission is required")
+	}
+
+	permissions := make([]string, 0, len(req.Permissions))
+	for _, p := range req.Permissions {
+		permissions = append(permissions, string(p))
+	}
+
+	params := s.params(req.RecvWindow)
+	params["subAccount"] = req.SubAccount
+	params["note"] = req.Note
+	params["permissions"] = strings.Join(permissions, ",")
+	if len(req.IPs) > 0 {
+		params["ip"] = strings.Join(req.IPs, ",")
+	}
+
+	var resp APIKey
+	if err := s.send(ctx, http.MethodPost, consts.EndpointSubAccountAPIKey, params, &resp); err != nil {
+		return nil, fmt.Errorf("create sub-account api key: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// ListAPIKeys https://mexcdevelop.github.io/apidocs/spot_v3_en/#query-the-apikey-of-a-sub-account-for-master-account
+func (s *Service) ListAPIKeys(ctx context.Context, subAccount string, recvWindow *int64) ([]APIKey, error) {
+	params := s.params(recvWindow)
+	params["subAccount"] = subAccount
+
+	var resp ListAPIKeysResponse
+	if err := s.send(ctx, http.MethodGet, consts.EndpointSubAccountAPIKey, params, &resp); err != nil {
+		return nil, fmt.Errorf("list sub-account api keys: %w", err)
+	}
+
+	return resp.SubAccount, nil
+}
+
+// DeleteAPIKey https://mexcdevelop.github.io/apidocs/spot_v3_en/#delete-the-apikey-of-a-sub-account-for-master-account
+func (s *Service) DeleteAPIKey(ctx context.Context, req DeleteAPIKeyRequest) (*DeleteAPIKeyResponse, error) {
+	params := s.params(req.RecvWindow)
+	params["subAccount"] = req.SubAccount
+	params["apiKey"] = req.APIKey
+
+	var resp DeleteAPIKeyResponse
+	if err := s.send(ctx, http.MethodDelete, consts.EndpointSubAccountAPIKey, params, &resp); err != nil {
+		return nil, fmt.Errorf("delete sub-account api key: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// Asset returns sub-account balances
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#query-sub-account-asset
+func (s *Service) Asset(ctx context.Context, req AssetRequest) (*AssetResponse, error) {
+	params := s.params(req.RecvWindow)
+	params["subAccount"] = req.SubAccount
+	params["accountType"] = string(req.AccountType)
+
+	var resp AssetResponse
+	if err := s.send(ctx, http.MethodGet, consts.EndpointSubAccountAsset, params, &resp); err != nil {
+		return nil, fmt.Errorf("sub-account asset: %w", err)
+	}
+
+	return &resp, nil
+}
+
+func (s *Service) params(recvWindow *int64) map[string]string {
+	params := map[string]string{
+		"timestamp": s.timestamp(),
+	}
+	if recvWindow != nil {
+		params["recvWindow"] = fmt.Sprintf("%d", *recvWindow)
+	}
+	return params
+}
+
+func (s *Service) send(ctx context.Context, method, endpoint string, params map[string]string, resp any) error {
+	body, err := s.client.SendRequest(ctx, method, endpoint, params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, resp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package mexchttpsubaccount
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	mexchttp "github.com/kattana-io/mexc-golang-sdk/http"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransport records requests of Service and answers them with response
+type fakeTransport struct {
+	requests []*http.Request
+	response string
+}
+
+func (f *fakeTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	f.requests = append(f.requests, r)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(f.response)),
+		Request:    r,
+	}, nil
+}
+
+func (f *fakeTransport) query(i int) url.Values {
+	return f.requests[i].URL.Query()
+}
+
+func newFakeService(response string) (*Service, *fakeTransport) {
+	transport := &fakeTransport{response: response}
+	client := mexchttp.NewClient("key", "secret", &http.Client{Transport: transport})
+	return New(client, func() string { return "1700000000000" }), transport
+}
+
+func TestService_CreateSubAccount(t *testing.T) {
+	s, transport := newFakeService(`{"subAccount":"sub1","note":"bot"}`)
+
+	recvWindow := int64(5000)
+	resp, err := s.CreateSubAccount(context.Background(), CreateSubAccountRequest{SubAccount: "sub1", Note: "bot", RecvWindow: &recvWindow})
+	require.NoError(t, err)
+	assert.Equal(t, "sub1", resp.SubAccount)
+
+	require.Len(t, transport.requests, 1)
+	assert.Equal(t, http.MethodPost, transport.requests[0].Method)
+	assert.Equal(t, "/api/v3/sub-account/virtualSubAccount", transport.requests[0].URL.Path)
+	query := transport.query(0)
+	assert.Equal(t, "sub1", query.Get("subAccount"))
+	assert.Equal(t, "bot", query.Get("note"))
+	assert.Equal(t, "5000", query.Get("recvWindow"))
+	assert.Equal(t, "1700000000000", query.Get("timestamp"))
+	assert.NotEmpty(t, query.Get("signature"))
+}
+
+func TestService_ListSubAccounts(t *testing.T) {
+	s, transport := newFakeService(`{"subAccounts":[{"subAccount":"sub1","isFreeze":true}]}`)
+
+	name, freeze, page, limit := "sub1", true, int32(2), int32(50)
+	resp, err := s.ListSubAccounts(context.Background(), ListSubAccountsRequest{SubAccount: &name, IsFreeze: &freeze, Page: &page, Limit: &limit})
+	require.NoError(t, err)
+	require.Len(t, resp.SubAccounts, 1)
+	assert.True(t, resp.SubAccounts[0].IsFreeze)
+
+	assert.Equal(t, http.MethodGet, transport.requests[0].Method)
+	assert.Equal(t, "/api/v3/sub-account/list", transport.requests[0].URL.Path)
+	query := transport.query(0)
+	assert.Equal(t, "sub1", query.Get("subAccount"))
+	assert.Equal(t, "true", query.Get("isFreeze"))
+	assert.Equal(t, "2", query.Get("page"))
+	assert.Equal(t, "50", query.Get("limit"))
+	assert.False(t, query.Has("recvWindow"))
+}
+
+func TestService_CreateAPIKey(t *testing.T) {
+	s, transport := newFakeService(`{"subAccount":"sub1","apiKey":"k","secretKey":"s"}`)
+
+	_, err := s.CreateAPIKey(context.Background(), CreateAPIKeyRequest{SubAccount: "sub1", Note: "bot"})
+	require.Error(t, err)
+	assert.Empty(t, transport.requests)
+
+	resp, err := s.CreateAPIKey(context.Background(), CreateAPIKeyRequest{
+		SubAccount:  "sub1",
+		Note:        "bot",
+		Permissions: []Permission{PermissionSpotAccountRead, PermissionSpotDealWrite},
+		IPs:         []string{"10.0.0.1", "10.0.0.2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "s", resp.SecretKey)
+
+	assert.Equal(t, http.MethodPost, transport.requests[0].Method)
+	assert.Equal(t, "/api/v3/sub-account/apiKey", transport.requests[0].URL.Path)
+	query := transport.query(0)
+	assert.Equal(t, "SPOT_ACCOUNT_READ,SPOT_DEAL_WRITE", query.Get("permissions"))
+	assert.Equal(t, "10.0.0.1,10.0.0.2", query.Get("ip"))
+}
+
+func TestService_DeleteAPIKey(t *testing.T) {
+	s, transport := newFakeService(`{"subAccount":"sub1"}`)
+
+	_, err := s.DeleteAPIKey(context.Background(), DeleteAPIKeyRequest{SubAccount: "sub1", APIKey: "k"})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodDelete, transport.requests[0].Method)
+	assert.Equal(t, "/api/v3/sub-account/apiKey", transport.requests[0].URL.Path)
+	assert.Equal(t, "k", transport.query(0).Get("apiKey"))
+}
+
+func TestService_Asset(t *testing.T) {
+	s, transport := newFakeService(`{"balances":[{"asset":"USDT","free":"10.5","locked":"1"}]}`)
+
+	resp, err := s.Asset(context.Background(), AssetRequest{SubAccount: "sub1", AccountType: mexchttpmarket.AccountTypeSpot})
+	require.NoError(t, err)
+	require.Len(t, resp.Balances, 1)
+	assert.Equal(t, "10.5", resp.Balances[0].Free.String())
+
+	assert.Equal(t, "/api/v3/sub-account/asset", transport.requests[0].URL.Path)
+	assert.Equal(t, "SPOT", transport.query(0).Get("accountType"))
+}
@@ -0,0 +1,171 @@
+package mexcledger
+
+import (
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	mexcwsuser "github.com/kattana-io/mexc-golang-sdk/websocket/user"
+	"github.com/shopspring/decimal"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fill is a single own trade
+type Fill struct {
+	TradeID         string
+	OrderID         string
+	ClientOrderID   string
+	Symbol          string
+	Side            mexchttpmarket.Side
+	Price           decimal.Decimal
+	Qty             decimal.Decimal
+	QuoteQty        decimal.Decimal
+	Commission      decimal.Decimal
+	CommissionAsset string
+	IsMaker         bool
+	Time            time.Time
+}
+
+func FillFromTrade(t *mexchttpmarket.GetAccountTradeListResponse) Fill {
+	fill := Fill{
+		TradeID:         t.ID,
+		OrderID:         t.OrderID,
+		Symbol:          t.Symbol,
+		Side:            mexchttpmarket.SideSell,
+		Price:           t.Price,
+		Qty:             t.Qty,
+		QuoteQty:        t.QuoteQty,
+		Commission:      t.Commission,
+		CommissionAsset: t.CommissionAsset,
+		IsMaker:         t.IsMaker,
+		Time:            time.UnixMilli(t.Time),
+	}
+	if t.IsBuyer {
+		fill.Side = mexchttpmarket.SideBuy
+	}
+	if t.ClientOrderID != nil {
+		fill.ClientOrderID = *t.ClientOrderID
+	}
+
+	return fill
+}
+
+// FillFromDeal converts private websocket deal, quote quantity is calculated if deal has no amount
+func FillFromDeal(deal *dto.PrivateDealsV3Api, symbol string) Fill {
+	fill := Fill{
+		TradeID:         deal.TradeId,
+		OrderID:         deal.OrderId,
+		ClientOrderID:   deal.ClientOrderId,
+		Symbol:          symbol,
+		Side:            mexchttpmarket.SideBuy,
+		Price:           parseDecimal(deal.Price),
+		Qty:             parseDecimal(deal.Quantity),
+		QuoteQty:        parseDecimal(deal.Amount),
+		Commission:      parseDecimal(deal.FeeAmount),
+		CommissionAsset: deal.FeeCurrency,
+		IsMaker:         deal.IsMaker,
+		Time:            time.UnixMilli(deal.Time),
+	}
+	if mexcwsuser.Side(deal.TradeType) == mexcwsuser.SideSell {
+		fill.Side = mexchttpmarket.SideSell
+	}
+	if fill.QuoteQty.IsZero() {
+		fill.QuoteQty = fill.Price.Mul(fill.Qty)
+	}
+
+	return fill
+}
+
+// FillLedger keeps fills per symbol ordered by time, fills with known trade ID are ignored,
+// so overlapping pages and replays may be added safely
+type FillLedger struct {
+	mtx   *sync.RWMutex
+	seen  map[string]struct{}
+	fills map[string][]Fill
+}
+
+func NewFillLedger() *FillLedger {
+	return &FillLedger{
+		mtx:   new(sync.RWMutex),
+		seen:  make(map[string]struct{}),
+		fills: make(map[string][]Fill),
+	}
+}
+
+// Add returns number of new fills
+func (l *FillLedger) Add(fills ...Fill) int {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	added := 0
+	for _, f := range fills {
+		key := f.Symbol + ":" + f.TradeID
+		if _, ok := l.seen[key]; ok {
+			continue
+		}
+		l.seen[key] = struct{}{}
+
+		symbolFills := l.fills[f.Symbol]
+		// fills mostly arrive in order, insert keeps order stable for equal times
+		i := sort.Search(len(symbolFills), func(i int) bool { return symbolFills[i].Time.After(f.Time) })
+		symbolFills = append(symbolFills, Fill{})
+		copy(symbolFills[i+1:], symbolFills[i:])
+		symbolFills[i] = f
+		l.fills[f.Symbol] = symbolFills
+		added++
+	}
+
+	return added
+}
+
+// AddTrades matches mexchttpmarket.Service.WalkAccountTrades callback
+func (l *FillLedger) AddTrades(trades []*mexchttpmarket.GetAccountTradeListResponse) error {
+	fills := make([]Fill, 0, len(trades))
+	for _, t := range trades {
+		fills = append(fills, FillFromTrade(t))
+	}
+	l.Add(fills...)
+
+	return nil
+}
+
+// HandleDeal matches mexcwsuser.Service.DealsSubscribe callback
+func (l *FillLedger) HandleDeal(deal *dto.PrivateDealsV3Api, symbol string) {
+	l.Add(FillFromDeal(deal, symbol))
+}
+
+// Fills returns copy of symbol fills ordered by time
+func (l *FillLedger) Fills(symbol string) []Fill {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+
+	return append([]Fill(nil), l.fills[symbol]...)
+}
+
+func (l *FillLedger) Symbols() []string {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+
+	symbols := make([]string, 0, len(l.fills))
+	for symbol := range l.fills {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	return symbols
+}
+
+func (l *FillLedger) Len() int {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+
+	return len(l.seen)
+}
+
+func parseDecimal(s string) decimal.Decimal {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
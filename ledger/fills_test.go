@@ -0,0 +1,36 @@
+package mexcledger
+
+import (
+	"testing"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trade(id string, t int64) *mexchttpmarket.GetAccountTradeListResponse {
+	return &mexchttpmarket.GetAccountTradeListResponse{
+		Symbol:  "BTCUSDT",
+		ID:      id,
+		Price:   decimal.NewFromInt(100),
+		Qty:     decimal.NewFromInt(1),
+		Time:    t,
+		IsBuyer: true,
+	}
+}
+
+func TestFillLedgerDedupe(t *testing.T) {
+	l := NewFillLedger()
+
+	require.NoError(t, l.AddTrades([]*mexchttpmarket.GetAccountTradeListResponse{trade("2", 20), trade("1", 10)}))
+	// overlapping page boundary
+	require.NoError(t, l.AddTrades([]*mexchttpmarket.GetAccountTradeListResponse{trade("2", 20), trade("3", 15)}))
+
+	fills := l.Fills("BTCUSDT")
+	require.Len(t, fills, 3)
+	assert.Equal(t, 3, l.Len())
+	assert.Equal(t, []string{"1", "3", "2"}, []string{fills[0].TradeID, fills[1].TradeID, fills[2].TradeID})
+	assert.Equal(t, mexchttpmarket.SideBuy, fills[0].Side)
+	assert.Equal(t, 0, l.Add(FillFromTrade(trade("1", 10))))
+}
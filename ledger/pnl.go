@@ -0,0 +1,278 @@
+package mexcledger
+
+import (
+	"encoding/csv"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"io"
+	"sort"
+	"strings"
+)
+
+type CostBasis string
+
+const (
+	CostBasisFIFO    CostBasis = "FIFO"
+	CostBasisLIFO    CostBasis = "LIFO"
+	CostBasisAverage CostBasis = "AVERAGE"
+)
+
+// PriceSource provides last price of symbol, e.g. Prices or mexcrisk.Prices
+type PriceSource interface {
+	Price(symbol string) (decimal.Decimal, bool)
+}
+
+// Prices is a static PriceSource
+type Prices map[string]decimal.Decimal
+
+func (p Prices) Price(symbol string) (decimal.Decimal, bool) {
+	price, ok := p[symbol]
+	return price, ok
+}
+
+func PricesFromTickers(tickers []*mexchttpmarket.TickerPriceResponse) Prices {
+	prices := make(Prices, len(tickers))
+	for _, t := range tickers {
+		prices[t.Symbol] = t.Price
+	}
+	return prices
+}
+
+type SymbolAssets struct {
+	Base  string
+	Quote string
+}
+
+func AssetsFromExchangeInfo(info *mexchttpmarket.ExchangeInfo) map[string]SymbolAssets {
+	assets := make(map[string]SymbolAssets, len(info.Symbols))
+	for i := range info.Symbols {
+		assets[info.Symbols[i].Symbol] = SymbolAssets{Base: info.Symbols[i].BaseAsset, Quote: info.Symbols[i].QuoteAsset}
+	}
+	return assets
+}
+
+// PnL of symbol, all amounts are in quote asset
+type PnL struct {
+	Symbol       string
+	QuoteAsset   string
+	CostBasis    CostBasis
+	TradeCount   int
+	BuyQty       decimal.Decimal
+	SellQty      decimal.Decimal
+	Position     decimal.Decimal // base quantity left open
+	AvgCost      decimal.Decimal // average cost of open position including buy fees
+	MarkPrice    decimal.Decimal
+	HasMarkPrice bool
+	Realised     decimal.Decimal // net of converted sell fees and buy fees of sold lots
+	Unrealised   decimal.Decimal // zero if mark price is unknown
+	Fees         decimal.Decimal // converted fees
+	UnmatchedQty decimal.Decimal // sold quantity without known cost, e.g. bought before history start
+	UnpricedFees map[string]decimal.Decimal
+}
+
+type lot struct {
+	qty   decimal.Decimal
+	price decimal.Decimal
+}
+
+// PnLCalculator computes PnL from fills of FillLedger. Fills are replayed in time order on every call,
+// so fills added out of order or from several sources (REST history, deals stream) give stable results.
+// Fees in base asset are converted by fill price, fees in other assets by current prices,
+// quantities stay gross of fees paid in base asset. Buy fees are added to cost of the bought lot.
+type PnLCalculator struct {
+	fills  *FillLedger
+	method CostBasis
+	assets map[string]SymbolAssets
+}
+
+// NewPnLCalculator assets maps symbol to its base and quote asset, see AssetsFromExchangeInfo
+func NewPnLCalculator(fills *FillLedger, method CostBasis, assets map[string]SymbolAssets) (*PnLCalculator, error) {
+	switch method {
+	case CostBasisFIFO, CostBasisLIFO, CostBasisAverage:
+	default:
+		return nil, fmt.Errorf("unknown cost basis %q", method)
+	}
+
+	return &PnLCalculator{
+		fills:  fills,
+		method: method,
+		assets: assets,
+	}, nil
+}
+
+// PnL of symbol, prices are used to mark open position and to convert fees paid in third assets
+func (c *PnLCalculator) PnL(symbol string, prices PriceSource) (*PnL, error) {
+	assets, ok := c.assets[symbol]
+	if !ok {
+		return nil, fmt.Errorf("unknown symbol %s", symbol)
+	}
+
+	res := &PnL{
+		Symbol:       symbol,
+		QuoteAsset:   assets.Quote,
+		UnpricedFees: make(map[string]decimal.Decimal),
+		CostBasis:    c.method,
+	}
+
+	var lots []lot
+	for _, f := range c.fills.Fills(symbol) {
+		res.TradeCount++
+
+		fee, ok := c.convertFee(f, assets, prices)
+		if ok {
+			res.Fees = res.Fees.Add(fee)
+		} else {
+			res.UnpricedFees[f.CommissionAsset] = res.UnpricedFees[f.CommissionAsset].Add(f.Commission)
+		}
+
+		if f.Side == mexchttpmarket.SideBuy {
+			res.BuyQty = res.BuyQty.Add(f.Qty)
+			price := f.Price
+			if f.Qty.IsPositive() {
+				price = price.Add(fee.Div(f.Qty))
+			}
+			lots = c.buy(lots, lot{qty: f.Qty, price: price})
+			continue
+		}
+
+		res.Realised = res.Realised.Sub(fee)
+
+		res.SellQty = res.SellQty.Add(f.Qty)
+		var realised, unmatched decimal.Decimal
+		lots, realised, unmatched = c.sell(lots, f.Qty, f.Price)
+		res.Realised = res.Realised.Add(realised)
+		res.UnmatchedQty = res.UnmatchedQty.Add(unmatched)
+	}
+
+	cost := decimal.Zero
+	for _, l := range lots {
+		res.Position = res.Position.Add(l.qty)
+		cost = cost.Add(l.qty.Mul(l.price))
+	}
+	if res.Position.IsPositive() {
+		res.AvgCost = cost.Div(res.Position)
+	}
+
+	if prices != nil {
+		res.MarkPrice, res.HasMarkPrice = prices.Price(symbol)
+	}
+	if res.HasMarkPrice {
+		res.Unrealised = res.Position.Mul(res.MarkPrice).Sub(cost)
+	}
+
+	return res, nil
+}
+
+// Report returns PnL of all symbols with fills ordered by symbol
+func (c *PnLCalculator) Report(prices PriceSource) ([]*PnL, error) {
+	symbols := c.fills.Symbols()
+	report := make([]*PnL, 0, len(symbols))
+	for _, symbol := range symbols {
+		pnl, err := c.PnL(symbol, prices)
+		if err != nil {
+			return nil, err
+		}
+		report = append(report, pnl)
+	}
+	return report, nil
+}
+
+func (c *PnLCalculator) buy(lots []lot, l lot) []lot {
+	if c.method != CostBasisAverage || len(lots) == 0 {
+		return append(lots, l)
+	}
+
+	qty := lots[0].qty.Add(l.qty)
+	price := lots[0].qty.Mul(lots[0].price).Add(l.qty.Mul(l.price)).Div(qty)
+	return []lot{{qty: qty, price: price}}
+}
+
+// sell consumes lots from the head for FIFO and from the tail for LIFO, average cost keeps a single lot
+func (c *PnLCalculator) sell(lots []lot, qty, price decimal.Decimal) ([]lot, decimal.Decimal, decimal.Decimal) {
+	realised := decimal.Zero
+	for qty.IsPositive() && len(lots) > 0 {
+		i := 0
+		if c.method == CostBasisLIFO {
+			i = len(lots) - 1
+		}
+
+		matched := decimal.Min(qty, lots[i].qty)
+		realised = realised.Add(price.Sub(lots[i].price).Mul(matched))
+		qty = qty.Sub(matched)
+		lots[i].qty = lots[i].qty.Sub(matched)
+
+		if lots[i].qty.IsZero() {
+			lots = append(lots[:i], lots[i+1:]...)
+		}
+	}
+
+	return lots, realised, qty
+}
+
+// convertFee converts commission into quote asset: base asset is priced by fill price,
+// other assets by ASSETQUOTE or inverse QUOTEASSET ticker
+func (c *PnLCalculator) convertFee(f Fill, assets SymbolAssets, prices PriceSource) (decimal.Decimal, bool) {
+	switch {
+	case f.Commission.IsZero():
+		return decimal.Zero, true
+	case f.CommissionAsset == assets.Quote:
+		return f.Commission, true
+	case f.CommissionAsset == assets.Base:
+		return f.Commission.Mul(f.Price), true
+	case prices == nil:
+		return decimal.Zero, false
+	}
+
+	if price, ok := prices.Price(f.CommissionAsset + assets.Quote); ok && price.IsPositive() {
+		return f.Commission.Mul(price), true
+	}
+	if price, ok := prices.Price(assets.Quote + f.CommissionAsset); ok && price.IsPositive() {
+		return f.Commission.Div(price), true
+	}
+
+	return decimal.Zero, false
+}
+
+var csvHeader = []string{
+	"symbol", "quote_asset", "cost_basis", "trades", "buy_qty", "sell_qty", "position", "avg_cost",
+	"mark_price", "realised", "unrealised", "fees", "unmatched_qty", "unpriced_fees",
+}
+
+// WriteCSV writes report with header, unknown mark price is written as empty value
+func WriteCSV(w io.Writer, report []*PnL) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, p := range report {
+		markPrice, unrealised := "", ""
+		if p.HasMarkPrice {
+			markPrice, unrealised = p.MarkPrice.String(), p.Unrealised.String()
+		}
+
+		assets := make([]string, 0, len(p.UnpricedFees))
+		for asset := range p.UnpricedFees {
+			assets = append(assets, asset)
+		}
+		sort.Strings(assets)
+		unpriced := make([]string, 0, len(assets))
+		for _, asset := range assets {
+			unpriced = append(unpriced, p.UnpricedFees[asset].String()+" "+asset)
+		}
+
+		err := cw.Write([]string{
+			p.Symbol, p.QuoteAsset, string(p.CostBasis), fmt.Sprintf("%d", p.TradeCount),
+			p.BuyQty.String(), p.SellQty.String(), p.Position.String(), p.AvgCost.String(),
+			markPrice, p.Realised.String(), unrealised, p.Fees.String(), p.UnmatchedQty.String(),
+			strings.Join(unpriced, ";"),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
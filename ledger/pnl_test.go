@@ -0,0 +1,91 @@
+package mexcledger
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fill(id string, side mexchttpmarket.Side, price, qty int64, t int64) Fill {
+	return Fill{
+		TradeID: id,
+		Symbol:  "BTCUSDT",
+		Side:    side,
+		Price:   decimal.NewFromInt(price),
+		Qty:     decimal.NewFromInt(qty),
+		Time:    time.UnixMilli(t),
+	}
+}
+
+func TestPnLCostBasis(t *testing.T) {
+	l := NewFillLedger()
+	l.Add(
+		fill("1", mexchttpmarket.SideBuy, 100, 1, 1),
+		fill("2", mexchttpmarket.SideBuy, 200, 1, 2),
+		fill("3", mexchttpmarket.SideSell, 300, 1, 3),
+	)
+	assets := map[string]SymbolAssets{"BTCUSDT": {Base: "BTC", Quote: "USDT"}}
+	prices := Prices{"BTCUSDT": decimal.NewFromInt(250)}
+
+	cases := map[CostBasis][2]int64{ // realised, unrealised
+		CostBasisFIFO:    {200, 50},
+		CostBasisLIFO:    {100, 150},
+		CostBasisAverage: {150, 100},
+	}
+	for method, expected := range cases {
+		c, err := NewPnLCalculator(l, method, assets)
+		require.NoError(t, err)
+
+		pnl, err := c.PnL("BTCUSDT", prices)
+		require.NoError(t, err)
+		assert.Equal(t, decimal.NewFromInt(expected[0]).String(), pnl.Realised.String(), method)
+		assert.Equal(t, decimal.NewFromInt(expected[1]).String(), pnl.Unrealised.String(), method)
+		assert.Equal(t, "1", pnl.Position.String(), method)
+	}
+}
+
+func TestPnLFees(t *testing.T) {
+	l := NewFillLedger()
+	buy := fill("1", mexchttpmarket.SideBuy, 100, 1, 1)
+	buy.Commission, buy.CommissionAsset = decimal.NewFromInt(2), "MX"
+	sell := fill("2", mexchttpmarket.SideSell, 110, 1, 2)
+	sell.Commission, sell.CommissionAsset = decimal.NewFromInt(1), "XYZ"
+	l.Add(buy, sell)
+
+	c, err := NewPnLCalculator(l, CostBasisFIFO, map[string]SymbolAssets{"BTCUSDT": {Base: "BTC", Quote: "USDT"}})
+	require.NoError(t, err)
+
+	report, err := c.Report(Prices{"MXUSDT": decimal.NewFromInt(3)})
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+	assert.Equal(t, "4", report[0].Realised.String())
+	assert.Equal(t, "6", report[0].Fees.String())
+	assert.Equal(t, "1", report[0].UnpricedFees["XYZ"].String())
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, report))
+	assert.Contains(t, buf.String(), "BTCUSDT,USDT,FIFO,2,1,1,0,0,,4,,6,0,1 XYZ")
+}
+
+func TestPnLBuyFeeCapitalised(t *testing.T) {
+	l := NewFillLedger()
+	buy := fill("1", mexchttpmarket.SideBuy, 100, 2, 1)
+	buy.Commission, buy.CommissionAsset = decimal.NewFromInt(4), "USDT"
+	l.Add(buy, fill("2", mexchttpmarket.SideSell, 110, 1, 2))
+
+	c, err := NewPnLCalculator(l, CostBasisFIFO, map[string]SymbolAssets{"BTCUSDT": {Base: "BTC", Quote: "USDT"}})
+	require.NoError(t, err)
+
+	// buy fee is a part of lot cost, only the sold half of it is realised
+	pnl, err := c.PnL("BTCUSDT", Prices{"BTCUSDT": decimal.NewFromInt(110)})
+	require.NoError(t, err)
+	assert.Equal(t, "8", pnl.Realised.String())
+	assert.Equal(t, "8", pnl.Unrealised.String())
+	assert.Equal(t, "102", pnl.AvgCost.String())
+	assert.Equal(t, "4", pnl.Fees.String())
+}
@@ -0,0 +1,102 @@
+package mexcledger
+
+import (
+	"encoding/csv"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RebateDay is a sum of rebates of asset and type received in a day
+type RebateDay struct {
+	Day    string // YYYY-MM-DD in aggregator location
+	Asset  string
+	Type   string
+	Amount decimal.Decimal
+	Count  int
+}
+
+// RebateAggregator sums rebate details per day, asset and type
+type RebateAggregator struct {
+	loc *time.Location
+
+	mtx  *sync.Mutex
+	days map[[3]string]*RebateDay
+}
+
+// NewRebateAggregator loc sets day boundaries, UTC if nil
+func NewRebateAggregator(loc *time.Location) *RebateAggregator {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return &RebateAggregator{
+		loc:  loc,
+		mtx:  new(sync.Mutex),
+		days: make(map[[3]string]*RebateDay),
+	}
+}
+
+// Add matches mexchttpmarket.Service.WalkRebateDetails callback
+func (a *RebateAggregator) Add(details []mexchttpmarket.RebateDetail) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	for _, d := range details {
+		day := time.UnixMilli(d.TradeTime).In(a.loc).Format(time.DateOnly)
+		key := [3]string{day, d.Asset, d.Type}
+
+		sum, ok := a.days[key]
+		if !ok {
+			sum = &RebateDay{Day: day, Asset: d.Asset, Type: d.Type}
+			a.days[key] = sum
+		}
+		sum.Amount = sum.Amount.Add(d.Amount)
+		sum.Count++
+	}
+
+	return nil
+}
+
+// Summary returns sums ordered by day, asset and type
+func (a *RebateAggregator) Summary() []RebateDay {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	res := make([]RebateDay, 0, len(a.days))
+	for _, d := range a.days {
+		res = append(res, *d)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Day != res[j].Day {
+			return res[i].Day < res[j].Day
+		}
+		if res[i].Asset != res[j].Asset {
+			return res[i].Asset < res[j].Asset
+		}
+		return res[i].Type < res[j].Type
+	})
+
+	return res
+}
+
+// WriteRebateCSV writes summary with header
+func WriteRebateCSV(w io.Writer, summary []RebateDay) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"day", "asset", "type", "amount", "count"}); err != nil {
+		return err
+	}
+
+	for _, d := range summary {
+		if err := cw.Write([]string{d.Day, d.Asset, d.Type, d.Amount.String(), fmt.Sprintf("%d", d.Count)}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,26 @@
+package mexcledger
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebateAggregator(t *testing.T) {
+	a := NewRebateAggregator(nil)
+	day := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC).UnixMilli()
+	require.NoError(t, a.Add([]mexchttpmarket.RebateDetail{
+		{Asset: "USDT", Type: "spot", Amount: decimal.RequireFromString("0.5"), TradeTime: day},
+		{Asset: "USDT", Type: "spot", Amount: decimal.RequireFromString("0.25"), TradeTime: day + 1000},
+		{Asset: "USDT", Type: "spot", Amount: decimal.RequireFromString("1"), TradeTime: day + 24*3600*1000},
+	}))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteRebateCSV(&buf, a.Summary()))
+	assert.Equal(t, "day,asset,type,amount,count\n2024-05-01,USDT,spot,0.75,2\n2024-05-02,USDT,spot,1,1\n", buf.String())
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	mexchttp "github.com/kattana-io/mexc-golang-sdk/http"
 	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	mexchttpsubaccount "github.com/kattana-io/mexc-golang-sdk/http/subaccount"
 	"github.com/kattana-io/mexc-golang-sdk/websocket"
 	"github.com/kattana-io/mexc-golang-sdk/websocket/market"
 )
 
 type Rest struct {
-	MarketService *mexchttpmarket.Service
+	MarketService     *mexchttpmarket.Service
+	SubAccountService *mexchttpsubaccount.Service
 }
 
 func NewRest(ctx context.Context, mexcHTTP *mexchttp.Client) (*Rest, error) {
@@ -19,7 +21,8 @@ func NewRest(ctx context.Context, mexcHTTP *mexchttp.Client) (*Rest, error) {
 	}
 
 	return &Rest{
-		MarketService: marketService,
+		MarketService:     marketService,
+		SubAccountService: mexchttpsubaccount.New(mexcHTTP, marketService.Timestamp),
 	}, nil
 }
 
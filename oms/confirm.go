@@ -0,0 +1,180 @@
+package mexcoms
+
+import (
+	"context"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultPollInterval = time.Second
+	eventRetention      = time.Minute
+)
+
+// OrderService is a part of market service used to place and query orders
+type OrderService interface {
+	CreateOrder(ctx context.Context, req *mexchttpmarket.CreateOrderRequest) (*mexchttpmarket.CreateOrderResponse, error)
+	QueryOrder(ctx context.Context, req *mexchttpmarket.GetOrderRequest) (*mexchttpmarket.GetOrderResponse, error)
+}
+
+// Confirmer resolves order status after ack response. It waits for private websocket order event
+// and falls back to REST polling if event doesn't come during poll interval.
+type Confirmer struct {
+	rest         OrderService
+	pollInterval time.Duration
+	mtx          *sync.Mutex
+	recent       map[string]recentOrder
+	waiters      map[string][]chan *mexchttpmarket.GetOrderResponse
+}
+
+type recentOrder struct {
+	order      *mexchttpmarket.GetOrderResponse
+	receivedAt time.Time
+}
+
+func NewConfirmer(rest OrderService, pollInterval time.Duration) *Confirmer {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	return &Confirmer{
+		rest:         rest,
+		pollInterval: pollInterval,
+		mtx:          new(sync.Mutex),
+		recent:       make(map[string]recentOrder),
+		waiters:      make(map[string][]chan *mexchttpmarket.GetOrderResponse),
+	}
+}
+
+// HandleOrderEvent consumes private order events, pass it as mexcwsuser.Service.OrdersSubscribe callback
+func (c *Confirmer) HandleOrderEvent(event *dto.PrivateOrdersV3Api, symbol string) {
+	order := OrderFromEvent(event, symbol)
+	now := time.Now()
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for id, r := range c.recent {
+		if now.Sub(r.receivedAt) > eventRetention {
+			delete(c.recent, id)
+		}
+	}
+	c.recent[order.OrderId] = recentOrder{order: order, receivedAt: now}
+
+	for _, ch := range c.waiters[order.OrderId] {
+		// keep the latest state only, consumer is interested in the current status
+		select {
+		case ch <- order:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- order
+		}
+	}
+}
+
+// CreateOrder places order and confirms its status if exchange returned ack only
+func (c *Confirmer) CreateOrder(ctx context.Context, req *mexchttpmarket.CreateOrderRequest) (*mexchttpmarket.GetOrderResponse, error) {
+	resp, err := c.rest.CreateOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsAck() {
+		return resp.Order(), nil
+	}
+
+	return c.Confirm(ctx, req.Symbol, resp.OrderId)
+}
+
+// Confirm returns the first known order status
+func (c *Confirmer) Confirm(ctx context.Context, symbol, orderID string) (*mexchttpmarket.GetOrderResponse, error) {
+	return c.wait(ctx, symbol, orderID, func(o *mexchttpmarket.GetOrderResponse) bool {
+		return o.Status != ""
+	})
+}
+
+// WaitFinal waits until order is filled or cancelled
+func (c *Confirmer) WaitFinal(ctx context.Context, symbol, orderID string) (*mexchttpmarket.GetOrderResponse, error) {
+	return c.wait(ctx, symbol, orderID, func(o *mexchttpmarket.GetOrderResponse) bool {
+		return o.Status.IsFinal()
+	})
+}
+
+func (c *Confirmer) wait(ctx context.Context, symbol, orderID string,
+	done func(*mexchttpmarket.GetOrderResponse) bool) (*mexchttpmarket.GetOrderResponse, error) {
+	ch, cached := c.subscribe(orderID)
+	defer c.unsubscribe(orderID, ch)
+
+	if cached != nil && done(cached) {
+		return cached, nil
+	}
+
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return nil, fmt.Errorf("confirm order %s: %w, last query error: %v", orderID, ctx.Err(), lastErr)
+			}
+			return nil, fmt.Errorf("confirm order %s: %w", orderID, ctx.Err())
+		case order := <-ch:
+			if done(order) {
+				return order, nil
+			}
+		case <-ticker.C:
+			order, err := c.rest.QueryOrder(ctx, &mexchttpmarket.GetOrderRequest{
+				Symbol:  symbol,
+				OrderID: &orderID,
+			})
+			if err != nil {
+				// order may be not visible yet, keep polling
+				lastErr = err
+				continue
+			}
+			if done(order) {
+				return order, nil
+			}
+		}
+	}
+}
+
+func (c *Confirmer) subscribe(orderID string) (chan *mexchttpmarket.GetOrderResponse, *mexchttpmarket.GetOrderResponse) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	ch := make(chan *mexchttpmarket.GetOrderResponse, 1)
+	c.waiters[orderID] = append(c.waiters[orderID], ch)
+
+	if r, ok := c.recent[orderID]; ok {
+		return ch, r.order
+	}
+	return ch, nil
+}
+
+func (c *Confirmer) unsubscribe(orderID string, ch chan *mexchttpmarket.GetOrderResponse) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	waiters := c.waiters[orderID]
+	for i := range waiters {
+		if waiters[i] == ch {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+
+	if len(waiters) == 0 {
+		delete(c.waiters, orderID)
+		return
+	}
+	c.waiters[orderID] = waiters
+}
@@ -0,0 +1,86 @@
+package mexcoms
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOrderService struct {
+	created *mexchttpmarket.CreateOrderResponse
+	queried *mexchttpmarket.GetOrderResponse
+	queries atomic.Int32
+}
+
+func (f *fakeOrderService) CreateOrder(_ context.Context,
+	_ *mexchttpmarket.CreateOrderRequest) (*mexchttpmarket.CreateOrderResponse, error) {
+	return f.created, nil
+}
+
+func (f *fakeOrderService) QueryOrder(_ context.Context,
+	_ *mexchttpmarket.GetOrderRequest) (*mexchttpmarket.GetOrderResponse, error) {
+	f.queries.Add(1)
+	if f.queried == nil {
+		return nil, errors.New("order does not exist")
+	}
+	return f.queried, nil
+}
+
+func TestConfirmer_WebsocketEvent(t *testing.T) {
+	rest := &fakeOrderService{
+		created: &mexchttpmarket.CreateOrderResponse{Symbol: "BTCUSDT", OrderId: "42"},
+	}
+	c := NewConfirmer(rest, time.Hour)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		c.HandleOrderEvent(&dto.PrivateOrdersV3Api{
+			Id:                 "42",
+			ClientId:           "cid",
+			Price:              "100",
+			Quantity:           "1",
+			CumulativeQuantity: "1",
+			TradeType:          2,
+			OrderType:          1,
+			Status:             2,
+		}, "BTCUSDT")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	order, err := c.CreateOrder(ctx, &mexchttpmarket.CreateOrderRequest{Symbol: "BTCUSDT"})
+	require.NoError(t, err)
+	assert.Equal(t, mexchttpmarket.StatusFilled, order.Status)
+	assert.Equal(t, mexchttpmarket.SideSell, order.Side)
+	assert.Equal(t, mexchttpmarket.TypeLimit, order.Type)
+	assert.Equal(t, "1", order.ExecutedQty.String())
+	assert.Equal(t, int32(0), rest.queries.Load())
+}
+
+func TestConfirmer_RestFallback(t *testing.T) {
+	rest := &fakeOrderService{
+		queried: &mexchttpmarket.GetOrderResponse{OrderId: "42", Status: mexchttpmarket.StatusNew},
+	}
+	c := NewConfirmer(rest, 5*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	order, err := c.Confirm(ctx, "BTCUSDT", "42")
+	require.NoError(t, err)
+	assert.Equal(t, mexchttpmarket.StatusNew, order.Status)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.WaitFinal(ctx, "BTCUSDT", "42")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
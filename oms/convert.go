@@ -0,0 +1,59 @@
+package mexcoms
+
+import (
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	mexcwsuser "github.com/kattana-io/mexc-golang-sdk/websocket/user"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	wsStatuses = map[mexcwsuser.Status]mexchttpmarket.Status{
+		mexcwsuser.StatusNew:                mexchttpmarket.StatusNew,
+		mexcwsuser.StatusFilled:             mexchttpmarket.StatusFilled,
+		mexcwsuser.StatusPartiallyFilled:    mexchttpmarket.StatusPartiallyFilled,
+		mexcwsuser.StatusCancelled:          mexchttpmarket.StatusCancelled,
+		mexcwsuser.StatusPartiallyCancelled: mexchttpmarket.StatusPartiallyCancelled,
+	}
+	wsTypes = map[mexcwsuser.Type]mexchttpmarket.Type{
+		mexcwsuser.TypeLimitOrder:        mexchttpmarket.TypeLimit,
+		mexcwsuser.TypePostOnly:          mexchttpmarket.TypeLimitMaker,
+		mexcwsuser.TypeImmediateOrCancel: mexchttpmarket.TypeImmediateOrCancel,
+		mexcwsuser.TypeFillOrKill:        mexchttpmarket.TypeFillOrKill,
+		mexcwsuser.TypeMarketOrder:       mexchttpmarket.TypeMarket,
+	}
+)
+
+// OrderFromEvent converts private websocket order event into REST order representation
+func OrderFromEvent(event *dto.PrivateOrdersV3Api, symbol string) *mexchttpmarket.GetOrderResponse {
+	side := mexchttpmarket.SideBuy
+	if mexcwsuser.Side(event.TradeType) == mexcwsuser.SideSell {
+		side = mexchttpmarket.SideSell
+	}
+
+	status := wsStatuses[mexcwsuser.Status(event.Status)]
+
+	return &mexchttpmarket.GetOrderResponse{
+		Symbol:              symbol,
+		OrderId:             event.Id,
+		ClientOrderID:       event.ClientId,
+		Price:               parseDecimal(event.Price),
+		OrigQty:             parseDecimal(event.Quantity),
+		ExecutedQty:         parseDecimal(event.CumulativeQuantity),
+		CummulativeQuoteQty: parseDecimal(event.CumulativeAmount),
+		Status:              status,
+		Type:                wsTypes[mexcwsuser.Type(event.OrderType)],
+		Side:                side,
+		CreateTime:          event.CreateTime,
+		IsWorking:           status == mexchttpmarket.StatusNew || status == mexchttpmarket.StatusPartiallyFilled,
+		OrigQuoteOrderQty:   parseDecimal(event.Amount),
+	}
+}
+
+func parseDecimal(s string) decimal.Decimal {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
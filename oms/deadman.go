@@ -0,0 +1,181 @@
+package mexcoms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/connection"
+	"sync"
+	"time"
+)
+
+const defaultDeadManCheckInterval = time.Second
+
+// TriggerReason tells why dead man's switch fired
+type TriggerReason string
+
+const (
+	TriggerStreamDown      TriggerReason = "private stream down"
+	TriggerHeartbeatMissed TriggerReason = "heartbeat missed"
+)
+
+// CancelService is a part of market service used to cancel open orders
+type CancelService interface {
+	CancelOpenOrders(ctx context.Context, req *mexchttpmarket.CancelOpenOrdersRequest) ([]*mexchttpmarket.CancelOrderResponse, error)
+}
+
+type DeadManConfig struct {
+	Symbols          []string      // symbols to cancel orders on
+	StreamTimeout    time.Duration // max private stream downtime, 0 disables check
+	HeartbeatTimeout time.Duration // max interval between Heartbeat calls, 0 disables check
+	CheckInterval    time.Duration // defaults to one second
+}
+
+// DeadManReport describes cancellation made by dead man's switch
+type DeadManReport struct {
+	Reason      TriggerReason
+	TriggeredAt time.Time
+	Cancelled   []*mexchttpmarket.CancelOrderResponse
+	Err         error
+}
+
+// DeadManSwitch cancels all open orders on configured symbols through REST when private stream
+// is down longer than StreamTimeout or host application stops calling Heartbeat.
+// It fires once and re-arms after both stream and heartbeat are healthy again.
+// Note that it runs in the same process, so orders of killed process stay on the book.
+type DeadManSwitch struct {
+	rest     CancelService
+	cfg      DeadManConfig
+	onFire   func(*DeadManReport)
+	mtx      *sync.Mutex
+	downAt   time.Time // zero when stream is up
+	beatAt   time.Time
+	fired    bool
+	watching bool
+}
+
+func NewDeadManSwitch(rest CancelService, cfg DeadManConfig, onFire func(*DeadManReport)) (*DeadManSwitch, error) {
+	if len(cfg.Symbols) == 0 {
+		return nil, errors.New("no symbols configured")
+	}
+	if cfg.StreamTimeout <= 0 && cfg.HeartbeatTimeout <= 0 {
+		return nil, errors.New("both stream and heartbeat checks are disabled")
+	}
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = defaultDeadManCheckInterval
+	}
+
+	return &DeadManSwitch{
+		rest:   rest,
+		cfg:    cfg,
+		onFire: onFire,
+		mtx:    new(sync.Mutex),
+		beatAt: time.Now(),
+	}, nil
+}
+
+// Watch follows private stream connection state, e.g. connection returned by MEXCWebSocket.Connection(mexcwsuser.SpotOrdersChannel).
+// Returned function stops watching.
+func (d *DeadManSwitch) Watch(conn *connection.MEXCWebSocketConnection) func() {
+	return conn.AddStateListener(d.HandleConnectionState)
+}
+
+// HandleConnectionState matches mexcwstypes.OnStateChange
+func (d *DeadManSwitch) HandleConnectionState(connected bool) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.watching = true
+	switch {
+	case connected:
+		d.downAt = time.Time{}
+	case d.downAt.IsZero():
+		d.downAt = time.Now()
+	}
+}
+
+// Heartbeat must be called by host application more often than HeartbeatTimeout
+func (d *DeadManSwitch) Heartbeat() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.beatAt = time.Now()
+}
+
+// Run checks conditions every CheckInterval until ctx is done
+func (d *DeadManSwitch) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if reason, ok := d.check(now); ok {
+				report := d.Fire(ctx, reason)
+				if report.Err != nil {
+					// retry on the next check
+					d.rearm()
+				}
+				if d.onFire != nil {
+					d.onFire(report)
+				}
+			}
+		}
+	}
+}
+
+// check returns trigger reason if switch should fire, re-arms it when everything is healthy
+func (d *DeadManSwitch) check(now time.Time) (TriggerReason, bool) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	var reason TriggerReason
+	switch {
+	case d.cfg.StreamTimeout > 0 && d.watching && !d.downAt.IsZero() && now.Sub(d.downAt) > d.cfg.StreamTimeout:
+		reason = TriggerStreamDown
+	case d.cfg.HeartbeatTimeout > 0 && now.Sub(d.beatAt) > d.cfg.HeartbeatTimeout:
+		reason = TriggerHeartbeatMissed
+	default:
+		d.fired = false
+		return "", false
+	}
+
+	if d.fired {
+		return "", false
+	}
+	d.fired = true
+	return reason, true
+}
+
+func (d *DeadManSwitch) rearm() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.fired = false
+}
+
+// Fire cancels open orders on all configured symbols immediately
+func (d *DeadManSwitch) Fire(ctx context.Context, reason TriggerReason) *DeadManReport {
+	report := &DeadManReport{
+		Reason:      reason,
+		TriggeredAt: time.Now(),
+	}
+
+	var errs []error
+	for i := 0; i < len(d.cfg.Symbols); i += mexchttpmarket.MaxCancelSymbols {
+		symbols := d.cfg.Symbols[i:min(i+mexchttpmarket.MaxCancelSymbols, len(d.cfg.Symbols))]
+
+		cancelled, err := d.rest.CancelOpenOrders(ctx, &mexchttpmarket.CancelOpenOrdersRequest{Symbols: symbols})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cancel open orders %v: %w", symbols, err))
+			continue
+		}
+		report.Cancelled = append(report.Cancelled, cancelled...)
+	}
+	report.Err = errors.Join(errs...)
+
+	return report
+}
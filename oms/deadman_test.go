@@ -0,0 +1,79 @@
+package mexcoms
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCancelService struct {
+	calls [][]string
+	err   error
+}
+
+func (f *fakeCancelService) CancelOpenOrders(_ context.Context,
+	req *mexchttpmarket.CancelOpenOrdersRequest) ([]*mexchttpmarket.CancelOrderResponse, error) {
+	f.calls = append(f.calls, req.Symbols)
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	res := make([]*mexchttpmarket.CancelOrderResponse, 0, len(req.Symbols))
+	for _, symbol := range req.Symbols {
+		res = append(res, &mexchttpmarket.CancelOrderResponse{Symbol: symbol})
+	}
+	return res, nil
+}
+
+func TestDeadManSwitch_Check(t *testing.T) {
+	d, err := NewDeadManSwitch(&fakeCancelService{}, DeadManConfig{
+		Symbols:          []string{"BTCUSDT"},
+		StreamTimeout:    time.Second,
+		HeartbeatTimeout: time.Minute,
+	}, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	_, ok := d.check(now)
+	assert.False(t, ok)
+
+	d.HandleConnectionState(false)
+	_, ok = d.check(now)
+	assert.False(t, ok, "downtime is shorter than timeout")
+
+	reason, ok := d.check(now.Add(2 * time.Second))
+	require.True(t, ok)
+	assert.Equal(t, TriggerStreamDown, reason)
+
+	_, ok = d.check(now.Add(3 * time.Second))
+	assert.False(t, ok, "switch fires once")
+
+	d.HandleConnectionState(true)
+	_, ok = d.check(now.Add(4 * time.Second))
+	assert.False(t, ok, "switch is re-armed")
+
+	reason, ok = d.check(now.Add(2 * time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, TriggerHeartbeatMissed, reason)
+}
+
+func TestDeadManSwitch_Fire(t *testing.T) {
+	rest := &fakeCancelService{}
+	symbols := []string{"A", "B", "C", "D", "E", "F", "G"}
+	d, err := NewDeadManSwitch(rest, DeadManConfig{Symbols: symbols, HeartbeatTimeout: time.Minute}, nil)
+	require.NoError(t, err)
+
+	report := d.Fire(context.Background(), TriggerHeartbeatMissed)
+	require.NoError(t, report.Err)
+	assert.Equal(t, [][]string{{"A", "B", "C", "D", "E"}, {"F", "G"}}, rest.calls)
+	assert.Len(t, report.Cancelled, len(symbols))
+
+	rest.err = errors.New("unavailable")
+	report = d.Fire(context.Background(), TriggerHeartbeatMissed)
+	assert.ErrorIs(t, report.Err, rest.err)
+}
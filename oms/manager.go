@@ -0,0 +1,341 @@
+package mexcoms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	"sync"
+	"time"
+)
+
+// UpdateSource tells where order state change came from
+type UpdateSource string
+
+const (
+	UpdateSourceLocal     UpdateSource = "local"
+	UpdateSourceWebsocket UpdateSource = "websocket"
+	UpdateSourceReconcile UpdateSource = "reconcile"
+)
+
+// OrderUpdate is delivered to subscribers on every accepted order state change
+type OrderUpdate struct {
+	Order          mexchttpmarket.GetOrderResponse
+	PreviousStatus mexchttpmarket.Status
+	Source         UpdateSource
+}
+
+// ManagerService is a part of market service used for reconciliation
+type ManagerService interface {
+	QueryOrder(ctx context.Context, req *mexchttpmarket.GetOrderRequest) (*mexchttpmarket.GetOrderResponse, error)
+	OpenOrders(ctx context.Context, req *mexchttpmarket.OpenOrdersRequest) ([]*mexchttpmarket.GetOrderResponse, error)
+}
+
+// Manager tracks orders by client order id through NEW -> PARTIALLY_FILLED -> FILLED/CANCELLED.
+// State is fed by private websocket order events and reconciled against REST after stream gaps.
+type Manager struct {
+	rest      ManagerService
+	mtx       *sync.RWMutex
+	orders    map[string]*mexchttpmarket.GetOrderResponse
+	byOrderID map[string]string
+	symbols   map[string]struct{}
+	subs      map[int]func(OrderUpdate)
+	nextSubID int
+	gap       chan struct{}
+}
+
+func NewManager(rest ManagerService) *Manager {
+	return &Manager{
+		rest:      rest,
+		mtx:       new(sync.RWMutex),
+		orders:    make(map[string]*mexchttpmarket.GetOrderResponse),
+		byOrderID: make(map[string]string),
+		symbols:   make(map[string]struct{}),
+		subs:      make(map[int]func(OrderUpdate)),
+		gap:       make(chan struct{}, 1),
+	}
+}
+
+// TrackSymbol makes reconciliation pick up open orders of symbol placed outside of this manager
+func (m *Manager) TrackSymbol(symbol string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.symbols[symbol] = struct{}{}
+}
+
+// Track registers order placed through REST, e.g. CreateOrderResponse.Order()
+func (m *Manager) Track(order *mexchttpmarket.GetOrderResponse) {
+	if order.Status == "" {
+		// ack response, the order is live until exchange tells otherwise
+		o := *order
+		o.Status = mexchttpmarket.StatusNew
+		o.IsWorking = true
+		order = &o
+	}
+
+	m.apply(order, UpdateSourceLocal)
+}
+
+// HandleOrderEvent consumes private order events, pass it as mexcwsuser.Service.OrdersSubscribe callback
+func (m *Manager) HandleOrderEvent(event *dto.PrivateOrdersV3Api, symbol string) {
+	m.apply(OrderFromEvent(event, symbol), UpdateSourceWebsocket)
+}
+
+// HandleStreamError matches mexcwstypes.OnError and schedules reconciliation
+// because events may be lost while connection is re-established
+func (m *Manager) HandleStreamError(_ bool, _ error) {
+	m.NotifyGap()
+}
+
+// NotifyGap schedules reconciliation on the next Run iteration
+func (m *Manager) NotifyGap() {
+	select {
+	case m.gap <- struct{}{}:
+	default:
+	}
+}
+
+// Run reconciles state every interval and after each reported stream gap until ctx is done
+func (m *Manager) Run(ctx context.Context, interval time.Duration, errCallback func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.gap:
+		}
+
+		if err := m.Reconcile(ctx); err != nil && errCallback != nil {
+			errCallback(err)
+		}
+	}
+}
+
+// Reconcile fetches open orders of every tracked symbol, orders which are open locally
+// but missing on the exchange are resolved with QueryOrder
+func (m *Manager) Reconcile(ctx context.Context) error {
+	symbols, open := m.reconcileTargets()
+
+	var errs []error
+	failed := make(map[string]struct{})
+	for symbol := range symbols {
+		orders, err := m.rest.OpenOrders(ctx, &mexchttpmarket.OpenOrdersRequest{Symbol: symbol})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("open orders %s: %w", symbol, err))
+			failed[symbol] = struct{}{}
+			continue
+		}
+
+		for _, o := range orders {
+			m.apply(o, UpdateSourceReconcile)
+			delete(open, o.OrderId)
+		}
+	}
+
+	for orderID, symbol := range open {
+		if _, ok := failed[symbol]; ok {
+			// can't tell whether order is still open
+			continue
+		}
+
+		order, err := m.rest.QueryOrder(ctx, &mexchttpmarket.GetOrderRequest{Symbol: symbol, OrderID: &orderID})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("query order %s: %w", orderID, err))
+			continue
+		}
+		m.apply(order, UpdateSourceReconcile)
+	}
+
+	return errors.Join(errs...)
+}
+
+// reconcileTargets returns symbols to query and locally open orders by order id
+func (m *Manager) reconcileTargets() (symbols map[string]struct{}, open map[string]string) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	symbols = make(map[string]struct{}, len(m.symbols))
+	for s := range m.symbols {
+		symbols[s] = struct{}{}
+	}
+
+	open = make(map[string]string)
+	for _, o := range m.orders {
+		if !o.Status.IsFinal() {
+			open[o.OrderId] = o.Symbol
+			symbols[o.Symbol] = struct{}{}
+		}
+	}
+
+	return symbols, open
+}
+
+// Order returns copy of order state by client order id
+func (m *Manager) Order(clientOrderID string) (mexchttpmarket.GetOrderResponse, bool) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	o, ok := m.orders[clientOrderID]
+	if !ok {
+		return mexchttpmarket.GetOrderResponse{}, false
+	}
+	return *o, true
+}
+
+// Snapshot returns consistent copy of all tracked orders
+func (m *Manager) Snapshot() []mexchttpmarket.GetOrderResponse {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]mexchttpmarket.GetOrderResponse, 0, len(m.orders))
+	for _, o := range m.orders {
+		res = append(res, *o)
+	}
+	return res
+}
+
+// OpenOrders returns copy of orders which are not filled or cancelled yet
+func (m *Manager) OpenOrders() []mexchttpmarket.GetOrderResponse {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	res := make([]mexchttpmarket.GetOrderResponse, 0)
+	for _, o := range m.orders {
+		if !o.Status.IsFinal() {
+			res = append(res, *o)
+		}
+	}
+	return res
+}
+
+// Forget removes finished orders updated before given time
+func (m *Manager) Forget(before time.Time) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for key, o := range m.orders {
+		if o.Status.IsFinal() && o.UpdateTime < before.UnixMilli() {
+			delete(m.orders, key)
+			delete(m.byOrderID, o.OrderId)
+		}
+	}
+}
+
+// Subscribe registers callback for order changes. Callbacks are called synchronously
+// by the goroutine which applied the change, so they must not block. Returned func removes subscription.
+func (m *Manager) Subscribe(callback func(OrderUpdate)) func() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	id := m.nextSubID
+	m.nextSubID++
+	m.subs[id] = callback
+
+	return func() {
+		m.mtx.Lock()
+		defer m.mtx.Unlock()
+		delete(m.subs, id)
+	}
+}
+
+func (m *Manager) apply(order *mexchttpmarket.GetOrderResponse, source UpdateSource) {
+	m.mtx.Lock()
+
+	key := m.key(order)
+	current, ok := m.orders[key]
+	if ok && !isNewer(current, order) {
+		m.mtx.Unlock()
+		return
+	}
+
+	updated := merge(current, order)
+	m.orders[key] = updated
+	if updated.OrderId != "" {
+		m.byOrderID[updated.OrderId] = key
+	}
+
+	update := OrderUpdate{Order: *updated, Source: source}
+	if current != nil {
+		update.PreviousStatus = current.Status
+	}
+
+	subs := make([]func(OrderUpdate), 0, len(m.subs))
+	for _, s := range m.subs {
+		subs = append(subs, s)
+	}
+	m.mtx.Unlock()
+
+	for _, s := range subs {
+		s(update)
+	}
+}
+
+// key returns client order id, exchange order id is used for orders placed without it
+func (m *Manager) key(order *mexchttpmarket.GetOrderResponse) string {
+	if key, ok := m.byOrderID[order.OrderId]; ok && order.OrderId != "" {
+		return key
+	}
+	if order.ClientOrderID != "" {
+		return order.ClientOrderID
+	}
+	return order.OrderId
+}
+
+// isNewer doesn't let stale events move order backwards
+func isNewer(current, next *mexchttpmarket.GetOrderResponse) bool {
+	if current.Status.IsFinal() {
+		return false
+	}
+
+	cr, nr := statusRank(current.Status), statusRank(next.Status)
+	if nr != cr {
+		return nr > cr
+	}
+
+	return next.ExecutedQty.GreaterThan(current.ExecutedQty)
+}
+
+func statusRank(s mexchttpmarket.Status) int {
+	switch {
+	case s.IsFinal():
+		return 2
+	case s == mexchttpmarket.StatusPartiallyFilled:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// merge keeps fields which websocket events don't carry
+func merge(current, next *mexchttpmarket.GetOrderResponse) *mexchttpmarket.GetOrderResponse {
+	o := *next
+	if o.UpdateTime == 0 {
+		o.UpdateTime = time.Now().UnixMilli()
+	}
+	if current == nil {
+		return &o
+	}
+
+	if o.ClientOrderID == "" {
+		o.ClientOrderID = current.ClientOrderID
+	}
+	if o.Symbol == "" {
+		o.Symbol = current.Symbol
+	}
+	if o.CreateTime == 0 {
+		o.CreateTime = current.CreateTime
+	}
+	if o.TimeInForce == "" {
+		o.TimeInForce = current.TimeInForce
+	}
+	if o.Type == "" {
+		o.Type = current.Type
+	}
+
+	return &o
+}
@@ -0,0 +1,79 @@
+package mexcoms
+
+import (
+	"context"
+	"testing"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeManagerService struct {
+	open   []*mexchttpmarket.GetOrderResponse
+	orders map[string]*mexchttpmarket.GetOrderResponse
+}
+
+func (f *fakeManagerService) QueryOrder(_ context.Context,
+	req *mexchttpmarket.GetOrderRequest) (*mexchttpmarket.GetOrderResponse, error) {
+	return f.orders[*req.OrderID], nil
+}
+
+func (f *fakeManagerService) OpenOrders(_ context.Context,
+	_ *mexchttpmarket.OpenOrdersRequest) ([]*mexchttpmarket.GetOrderResponse, error) {
+	return f.open, nil
+}
+
+func TestManager_Lifecycle(t *testing.T) {
+	m := NewManager(&fakeManagerService{})
+
+	var updates []OrderUpdate
+	unsubscribe := m.Subscribe(func(u OrderUpdate) {
+		updates = append(updates, u)
+	})
+	defer unsubscribe()
+
+	m.Track((&mexchttpmarket.CreateOrderResponse{Symbol: "BTCUSDT", OrderId: "1", ClientOrderID: "c1"}).Order())
+
+	m.HandleOrderEvent(&dto.PrivateOrdersV3Api{Id: "1", Status: 3, CumulativeQuantity: "0.5"}, "BTCUSDT")
+	m.HandleOrderEvent(&dto.PrivateOrdersV3Api{Id: "1", Status: 2, CumulativeQuantity: "1"}, "BTCUSDT")
+	// late partial fill must not move order back
+	m.HandleOrderEvent(&dto.PrivateOrdersV3Api{Id: "1", Status: 3, CumulativeQuantity: "0.7"}, "BTCUSDT")
+
+	order, ok := m.Order("c1")
+	require.True(t, ok)
+	assert.Equal(t, mexchttpmarket.StatusFilled, order.Status)
+	assert.True(t, decimal.NewFromInt(1).Equal(order.ExecutedQty))
+	assert.Equal(t, "c1", order.ClientOrderID)
+
+	require.Len(t, updates, 3)
+	assert.Equal(t, mexchttpmarket.StatusPartiallyFilled, updates[2].PreviousStatus)
+	assert.Empty(t, m.OpenOrders())
+}
+
+func TestManager_Reconcile(t *testing.T) {
+	rest := &fakeManagerService{
+		open: []*mexchttpmarket.GetOrderResponse{
+			{Symbol: "BTCUSDT", OrderId: "2", ClientOrderID: "c2", Status: mexchttpmarket.StatusNew},
+		},
+		orders: map[string]*mexchttpmarket.GetOrderResponse{
+			"1": {Symbol: "BTCUSDT", OrderId: "1", ClientOrderID: "c1", Status: mexchttpmarket.StatusCancelled},
+		},
+	}
+	m := NewManager(rest)
+	m.TrackSymbol("BTCUSDT")
+	m.Track(&mexchttpmarket.GetOrderResponse{Symbol: "BTCUSDT", OrderId: "1", ClientOrderID: "c1"})
+
+	require.NoError(t, m.Reconcile(context.Background()))
+
+	order, ok := m.Order("c1")
+	require.True(t, ok)
+	assert.Equal(t, mexchttpmarket.StatusCancelled, order.Status)
+
+	open := m.OpenOrders()
+	require.Len(t, open, 1)
+	assert.Equal(t, "c2", open[0].ClientOrderID)
+	assert.Len(t, m.Snapshot(), 2)
+}
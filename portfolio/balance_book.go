@@ -0,0 +1,314 @@
+package mexcportfolio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	"github.com/shopspring/decimal"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AccountService is a part of market service which returns balances
+type AccountService interface {
+	GetAccountInformation(ctx context.Context, req mexchttpmarket.AccountInformationRequest) (*mexchttpmarket.AccountInformationResponse, error)
+}
+
+type AssetBalance struct {
+	Asset     string
+	Free      decimal.Decimal
+	Locked    decimal.Decimal
+	UpdatedAt int64 // ms, time of the last applied update
+}
+
+func (b AssetBalance) Total() decimal.Decimal {
+	return b.Free.Add(b.Locked)
+}
+
+// Drift is a difference between streamed and REST balance found on reconciliation
+type Drift struct {
+	Asset      string
+	Book       AssetBalance
+	Rest       AssetBalance
+	FreeDiff   decimal.Decimal // REST minus book
+	LockedDiff decimal.Decimal
+}
+
+type BalanceBookConfig struct {
+	Tolerance decimal.Decimal // differences up to tolerance aren't reported
+	OnDrift   func([]Drift)   // optional, called after reconciliation found drift
+	// ServerTime returns exchange time in ms, e.g. mexchttpmarket.Service.ServerTimeMilli, local time if nil.
+	// It stamps snapshots without update time, which are compared with stream event times.
+	ServerTime func() int64
+}
+
+// BalanceBook keeps account balances, starts from REST snapshot and applies stream changes incrementally.
+// Stream events not newer than the last snapshot are skipped, they are already included in it.
+// Events received while snapshot is fetched are buffered and replayed on it if they are newer.
+type BalanceBook struct {
+	rest AccountService
+	cfg  BalanceBookConfig
+
+	mtx          *sync.RWMutex
+	balances     map[string]*AssetBalance
+	snapshotTime int64
+	fetching     int     // number of in-flight snapshot requests
+	buffered     []delta // changes applied while snapshot is fetched
+}
+
+// delta is a parsed balance change of stream event
+type delta struct {
+	asset  string
+	free   decimal.Decimal
+	locked decimal.Decimal
+	time   int64
+}
+
+func NewBalanceBook(rest AccountService, cfg BalanceBookConfig) *BalanceBook {
+	if cfg.ServerTime == nil {
+		cfg.ServerTime = func() int64 {
+			return time.Now().UnixMilli()
+		}
+	}
+
+	return &BalanceBook{
+		rest:     rest,
+		cfg:      cfg,
+		mtx:      new(sync.RWMutex),
+		balances: make(map[string]*AssetBalance),
+	}
+}
+
+// Load replaces book with REST balances
+func (b *BalanceBook) Load(ctx context.Context) error {
+	_, err := b.Reconcile(ctx)
+	return err
+}
+
+// Reconcile compares book with REST balances, reports drift and replaces book with REST balances.
+// Stream changes received during the request and newer than the snapshot are replayed on it.
+func (b *BalanceBook) Reconcile(ctx context.Context) ([]Drift, error) {
+	b.mtx.Lock()
+	b.fetching++
+	b.mtx.Unlock()
+
+	requestedAt := b.cfg.ServerTime()
+	account, err := b.rest.GetAccountInformation(ctx, mexchttpmarket.AccountInformationRequest{})
+	if err != nil {
+		b.mtx.Lock()
+		b.doneFetching()
+		b.mtx.Unlock()
+		return nil, fmt.Errorf("account information: %w", err)
+	}
+
+	snapshotTime := account.UpdateTime
+	if snapshotTime == 0 {
+		snapshotTime = requestedAt
+	}
+
+	rest := make(map[string]*AssetBalance, len(account.Balances))
+	for _, balance := range account.Balances {
+		rest[balance.Asset] = &AssetBalance{
+			Asset:     balance.Asset,
+			Free:      balance.Free,
+			Locked:    balance.Locked,
+			UpdatedAt: snapshotTime,
+		}
+	}
+
+	b.mtx.Lock()
+	for _, d := range b.buffered {
+		if d.time > snapshotTime {
+			applyDelta(rest, d)
+		}
+	}
+	b.doneFetching()
+
+	loaded := b.snapshotTime != 0
+	var drifts []Drift
+	if loaded {
+		drifts = b.drift(rest)
+	}
+	b.balances = rest
+	b.snapshotTime = snapshotTime
+	b.mtx.Unlock()
+
+	if len(drifts) > 0 && b.cfg.OnDrift != nil {
+		b.cfg.OnDrift(drifts)
+	}
+
+	return drifts, nil
+}
+
+// doneFetching drops buffered changes once no snapshot is fetched, mtx must be held
+func (b *BalanceBook) doneFetching() {
+	b.fetching--
+	if b.fetching == 0 {
+		b.buffered = nil
+	}
+}
+
+func (b *BalanceBook) drift(rest map[string]*AssetBalance) []Drift {
+	assets := make(map[string]struct{}, len(rest)+len(b.balances))
+	for asset := range rest {
+		assets[asset] = struct{}{}
+	}
+	for asset := range b.balances {
+		assets[asset] = struct{}{}
+	}
+
+	var drifts []Drift
+	for asset := range assets {
+		book, restBalance := AssetBalance{Asset: asset}, AssetBalance{Asset: asset}
+		if v, ok := b.balances[asset]; ok {
+			book = *v
+		}
+		if v, ok := rest[asset]; ok {
+			restBalance = *v
+		}
+
+		freeDiff := restBalance.Free.Sub(book.Free)
+		lockedDiff := restBalance.Locked.Sub(book.Locked)
+		if freeDiff.Abs().GreaterThan(b.cfg.Tolerance) || lockedDiff.Abs().GreaterThan(b.cfg.Tolerance) {
+			drifts = append(drifts, Drift{Asset: asset, Book: book, Rest: restBalance, FreeDiff: freeDiff, LockedDiff: lockedDiff})
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Asset < drifts[j].Asset })
+
+	return drifts
+}
+
+// Run reconciles book every interval until ctx is done
+func (b *BalanceBook) Run(ctx context.Context, interval time.Duration, errCallback func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := b.Reconcile(ctx); err != nil && errCallback != nil {
+				errCallback(err)
+			}
+		}
+	}
+}
+
+// HandleAccount matches mexcwsuser.Service.AccountSubscribe callback
+func (b *BalanceBook) HandleAccount(event *dto.PrivateAccountV3Api, _ string) {
+	// malformed event is healed by the next reconciliation
+	_ = b.Apply(event)
+}
+
+// Apply adds balance and frozen amount changes of stream event.
+// Events without time are always applied and can't be replayed, they are healed by the next reconciliation.
+func (b *BalanceBook) Apply(event *dto.PrivateAccountV3Api) error {
+	freeChange, err := parseChange(event.BalanceAmountChange)
+	if err != nil {
+		return fmt.Errorf("balance change of %s: %w", event.VcoinName, err)
+	}
+	lockedChange, err := parseChange(event.FrozenAmountChange)
+	if err != nil {
+		return fmt.Errorf("frozen change of %s: %w", event.VcoinName, err)
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if event.Time != 0 && event.Time <= b.snapshotTime {
+		return nil
+	}
+
+	d := delta{asset: event.VcoinName, free: freeChange, locked: lockedChange, time: event.Time}
+	applyDelta(b.balances, d)
+	if b.fetching > 0 {
+		b.buffered = append(b.buffered, d)
+	}
+
+	return nil
+}
+
+func applyDelta(balances map[string]*AssetBalance, d delta) {
+	balance, ok := balances[d.asset]
+	if !ok {
+		balance = &AssetBalance{Asset: d.asset}
+		balances[d.asset] = balance
+	}
+	balance.Free = balance.Free.Add(d.free)
+	balance.Locked = balance.Locked.Add(d.locked)
+	balance.UpdatedAt = d.time
+}
+
+func parseChange(s string) (decimal.Decimal, error) {
+	if s == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(s)
+}
+
+// Get returns balance of asset, ok is false if asset is unknown
+func (b *BalanceBook) Get(asset string) (AssetBalance, bool) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	balance, ok := b.balances[asset]
+	if !ok {
+		return AssetBalance{Asset: asset}, false
+	}
+	return *balance, true
+}
+
+func (b *BalanceBook) Free(asset string) decimal.Decimal {
+	balance, _ := b.Get(asset)
+	return balance.Free
+}
+
+func (b *BalanceBook) Locked(asset string) decimal.Decimal {
+	balance, _ := b.Get(asset)
+	return balance.Locked
+}
+
+func (b *BalanceBook) Total(asset string) decimal.Decimal {
+	balance, _ := b.Get(asset)
+	return balance.Total()
+}
+
+// Balance returns total balance, matches mexcrisk.BalanceSource
+func (b *BalanceBook) Balance(asset string) (decimal.Decimal, bool) {
+	balance, ok := b.Get(asset)
+	return balance.Total(), ok
+}
+
+// Snapshot returns non-zero balances ordered by asset
+func (b *BalanceBook) Snapshot() []AssetBalance {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	res := make([]AssetBalance, 0, len(b.balances))
+	for _, balance := range b.balances {
+		if !balance.Total().IsZero() {
+			res = append(res, *balance)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Asset < res[j].Asset })
+
+	return res
+}
+
+// ErrNotLoaded is returned by Loaded when book has no snapshot yet
+var ErrNotLoaded = errors.New("balance book is not loaded")
+
+// Loaded returns ErrNotLoaded until the first REST snapshot is applied
+func (b *BalanceBook) Loaded() error {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	if b.snapshotTime == 0 {
+		return ErrNotLoaded
+	}
+	return nil
+}
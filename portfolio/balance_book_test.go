@@ -0,0 +1,129 @@
+package mexcportfolio
+
+import (
+	"context"
+	"testing"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAccountService struct {
+	account *mexchttpmarket.AccountInformationResponse
+	during  func() // optional, called while request is in flight
+}
+
+func (f *fakeAccountService) GetAccountInformation(_ context.Context,
+	_ mexchttpmarket.AccountInformationRequest) (*mexchttpmarket.AccountInformationResponse, error) {
+	if f.during != nil {
+		f.during()
+	}
+	return f.account, nil
+}
+
+func TestBalanceBook(t *testing.T) {
+	rest := &fakeAccountService{account: &mexchttpmarket.AccountInformationResponse{
+		UpdateTime: 100,
+		Balances:   []mexchttpmarket.Balance{{Asset: "USDT", Free: decimal.NewFromInt(100)}},
+	}}
+	var drifts []Drift
+	book := NewBalanceBook(rest, BalanceBookConfig{OnDrift: func(d []Drift) { drifts = d }})
+	ctx := context.Background()
+
+	require.ErrorIs(t, book.Loaded(), ErrNotLoaded)
+	require.NoError(t, book.Load(ctx))
+
+	// stale event is already in snapshot
+	book.HandleAccount(&dto.PrivateAccountV3Api{VcoinName: "USDT", BalanceAmountChange: "-10", Time: 50}, "")
+	// order placed, 10 USDT frozen
+	book.HandleAccount(&dto.PrivateAccountV3Api{VcoinName: "USDT", BalanceAmountChange: "-10",
+		FrozenAmountChange: "10", Time: 150}, "")
+
+	assert.Equal(t, "90", book.Free("USDT").String())
+	assert.Equal(t, "10", book.Locked("USDT").String())
+	assert.Equal(t, "100", book.Total("USDT").String())
+
+	rest.account = &mexchttpmarket.AccountInformationResponse{
+		UpdateTime: 200,
+		Balances:   []mexchttpmarket.Balance{{Asset: "USDT", Free: decimal.NewFromInt(95), Locked: decimal.NewFromInt(10)}},
+	}
+	found, err := book.Reconcile(ctx)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, found, drifts)
+	assert.Equal(t, "5", found[0].FreeDiff.String())
+	assert.Equal(t, "95", book.Free("USDT").String())
+}
+
+func TestBalanceBook_ReplaysChangesDuringFetch(t *testing.T) {
+	rest := &fakeAccountService{account: &mexchttpmarket.AccountInformationResponse{
+		UpdateTime: 100,
+		Balances:   []mexchttpmarket.Balance{{Asset: "USDT", Free: decimal.NewFromInt(100)}},
+	}}
+	book := NewBalanceBook(rest, BalanceBookConfig{})
+	ctx := context.Background()
+	require.NoError(t, book.Load(ctx))
+
+	// snapshot taken at 200 includes change at 200, change at 250 arrives before response
+	rest.account = &mexchttpmarket.AccountInformationResponse{
+		UpdateTime: 200,
+		Balances:   []mexchttpmarket.Balance{{Asset: "USDT", Free: decimal.NewFromInt(90)}},
+	}
+	rest.during = func() {
+		book.HandleAccount(&dto.PrivateAccountV3Api{VcoinName: "USDT", BalanceAmountChange: "-10", Time: 200}, "")
+		book.HandleAccount(&dto.PrivateAccountV3Api{VcoinName: "USDT", BalanceAmountChange: "-5",
+			FrozenAmountChange: "5", Time: 250}, "")
+		book.HandleAccount(&dto.PrivateAccountV3Api{VcoinName: "BTC", BalanceAmountChange: "1", Time: 260}, "")
+	}
+
+	drifts, err := book.Reconcile(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+	assert.Equal(t, "85", book.Free("USDT").String())
+	assert.Equal(t, "5", book.Locked("USDT").String())
+	assert.Equal(t, "1", book.Free("BTC").String())
+
+	// buffer is dropped after reconciliation, change at snapshot time is skipped
+	rest.during = nil
+	rest.account = &mexchttpmarket.AccountInformationResponse{
+		UpdateTime: 300,
+		Balances:   []mexchttpmarket.Balance{{Asset: "USDT", Free: decimal.NewFromInt(85), Locked: decimal.NewFromInt(5)}},
+	}
+	_, err = book.Reconcile(ctx)
+	require.NoError(t, err)
+	book.HandleAccount(&dto.PrivateAccountV3Api{VcoinName: "USDT", BalanceAmountChange: "-1", Time: 300}, "")
+	assert.Equal(t, "85", book.Free("USDT").String())
+	assert.False(t, book.Free("BTC").IsPositive())
+}
+
+func TestBalanceBook_ServerTimeWithoutUpdateTime(t *testing.T) {
+	rest := &fakeAccountService{account: &mexchttpmarket.AccountInformationResponse{
+		Balances: []mexchttpmarket.Balance{{Asset: "USDT", Free: decimal.NewFromInt(100)}},
+	}}
+	// local clock is far ahead of exchange, snapshot is stamped with exchange time
+	serverTime := int64(1000)
+	book := NewBalanceBook(rest, BalanceBookConfig{ServerTime: func() int64 { return serverTime }})
+	ctx := context.Background()
+	require.NoError(t, book.Load(ctx))
+
+	book.HandleAccount(&dto.PrivateAccountV3Api{VcoinName: "USDT", BalanceAmountChange: "-10", Time: 900}, "")
+	book.HandleAccount(&dto.PrivateAccountV3Api{VcoinName: "USDT", BalanceAmountChange: "-5", Time: 1100}, "")
+	assert.Equal(t, "95", book.Free("USDT").String())
+
+	// snapshot at 2000 includes change at 1500, change at 2500 arrives before response
+	serverTime = 2000
+	rest.account = &mexchttpmarket.AccountInformationResponse{
+		Balances: []mexchttpmarket.Balance{{Asset: "USDT", Free: decimal.NewFromInt(94)}},
+	}
+	rest.during = func() {
+		book.HandleAccount(&dto.PrivateAccountV3Api{VcoinName: "USDT", BalanceAmountChange: "-1", Time: 1500}, "")
+		book.HandleAccount(&dto.PrivateAccountV3Api{VcoinName: "USDT", BalanceAmountChange: "-2", Time: 2500}, "")
+	}
+	drifts, err := book.Reconcile(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+	assert.Equal(t, "92", book.Free("USDT").String())
+}
@@ -0,0 +1,193 @@
+package mexcportfolio
+
+import (
+	"context"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/market"
+	"github.com/shopspring/decimal"
+	"sort"
+	"sync"
+)
+
+// DefaultIntermediates are assets tried in order when asset has no direct pair with quote
+var DefaultIntermediates = []string{"USDT", "BTC", "ETH", "USDC"}
+
+// Holdings provides balances to value, e.g. BalanceBook
+type Holdings interface {
+	Snapshot() []AssetBalance
+}
+
+// TickerService is a part of market service which returns last prices
+type TickerService interface {
+	TickerPrice(ctx context.Context, symbol string) ([]*mexchttpmarket.TickerPriceResponse, error)
+}
+
+type ValuatorConfig struct {
+	Intermediates []string // DefaultIntermediates if nil
+	OnPrices      func()   // optional, called after streamed prices are applied
+}
+
+// AssetValue of a single asset, Route lists assets the price was converted through
+type AssetValue struct {
+	Asset    string
+	Quantity decimal.Decimal
+	Price    decimal.Decimal
+	Value    decimal.Decimal
+	Weight   decimal.Decimal // share of total value, 0..1
+	Route    []string
+}
+
+type Valuation struct {
+	Quote    string
+	Total    decimal.Decimal
+	Assets   []AssetValue // ordered by value, descending
+	Unpriced []string     // assets without any route to quote
+}
+
+// Valuator values holdings in a chosen quote asset using last prices
+type Valuator struct {
+	holdings      Holdings
+	intermediates []string
+	onPrices      func()
+
+	mtx    *sync.RWMutex
+	prices map[string]decimal.Decimal
+}
+
+func NewValuator(holdings Holdings, cfg ValuatorConfig) *Valuator {
+	v := &Valuator{
+		holdings:      holdings,
+		intermediates: cfg.Intermediates,
+		onPrices:      cfg.OnPrices,
+		mtx:           new(sync.RWMutex),
+		prices:        make(map[string]decimal.Decimal),
+	}
+	if v.intermediates == nil {
+		v.intermediates = DefaultIntermediates
+	}
+	return v
+}
+
+// LoadPrices sets last prices of all symbols from REST ticker
+func (v *Valuator) LoadPrices(ctx context.Context, rest TickerService) error {
+	tickers, err := rest.TickerPrice(ctx, "")
+	if err != nil {
+		return fmt.Errorf("ticker price: %w", err)
+	}
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	for _, t := range tickers {
+		v.prices[t.Symbol] = t.Price
+	}
+	return nil
+}
+
+// Watch keeps prices updated from mini tickers stream of all symbols
+func (v *Valuator) Watch(ctx context.Context, ws *mexcwsmarket.Service, timezone string) error {
+	return ws.MiniTickersSubscribe(ctx, timezone, v.HandleMiniTickers)
+}
+
+// HandleMiniTickers matches mexcwsmarket.Service.MiniTickersSubscribe callback
+func (v *Valuator) HandleMiniTickers(api *dto.PublicMiniTickersV3Api) {
+	v.mtx.Lock()
+	for _, item := range api.Items {
+		price, err := decimal.NewFromString(item.Price)
+		if err != nil || !price.IsPositive() {
+			continue
+		}
+		v.prices[item.Symbol] = price
+	}
+	v.mtx.Unlock()
+
+	if v.onPrices != nil {
+		v.onPrices()
+	}
+}
+
+func (v *Valuator) SetPrice(symbol string, price decimal.Decimal) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	v.prices[symbol] = price
+}
+
+// Price returns price of asset in quote, directly, by inverse pair or through one of intermediates
+func (v *Valuator) Price(asset, quote string) (decimal.Decimal, []string, bool) {
+	v.mtx.RLock()
+	defer v.mtx.RUnlock()
+
+	if price, ok := v.pairPrice(asset, quote); ok {
+		return price, []string{asset, quote}, true
+	}
+
+	for _, intermediate := range v.intermediates {
+		if intermediate == asset || intermediate == quote {
+			continue
+		}
+		first, ok := v.pairPrice(asset, intermediate)
+		if !ok {
+			continue
+		}
+		second, ok := v.pairPrice(intermediate, quote)
+		if !ok {
+			continue
+		}
+		return first.Mul(second), []string{asset, intermediate, quote}, true
+	}
+
+	return decimal.Zero, nil, false
+}
+
+func (v *Valuator) pairPrice(base, quote string) (decimal.Decimal, bool) {
+	if base == quote {
+		return decimal.NewFromInt(1), true
+	}
+	if price, ok := v.prices[base+quote]; ok && price.IsPositive() {
+		return price, true
+	}
+	if price, ok := v.prices[quote+base]; ok && price.IsPositive() {
+		return decimal.NewFromInt(1).Div(price), true
+	}
+	return decimal.Zero, false
+}
+
+// Value returns valuation of current holdings in quote, e.g. USDT or BTC
+func (v *Valuator) Value(quote string) *Valuation {
+	res := &Valuation{Quote: quote}
+
+	for _, balance := range v.holdings.Snapshot() {
+		qty := balance.Total()
+		if qty.IsZero() {
+			continue
+		}
+
+		price, route, ok := v.Price(balance.Asset, quote)
+		if !ok {
+			res.Unpriced = append(res.Unpriced, balance.Asset)
+			continue
+		}
+
+		value := qty.Mul(price)
+		res.Total = res.Total.Add(value)
+		res.Assets = append(res.Assets, AssetValue{
+			Asset:    balance.Asset,
+			Quantity: qty,
+			Price:    price,
+			Value:    value,
+			Route:    route,
+		})
+	}
+
+	if res.Total.IsPositive() {
+		for i := range res.Assets {
+			res.Assets[i].Weight = res.Assets[i].Value.Div(res.Total)
+		}
+	}
+	sort.Slice(res.Assets, func(i, j int) bool { return res.Assets[i].Value.GreaterThan(res.Assets[j].Value) })
+
+	return res
+}
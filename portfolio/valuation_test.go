@@ -0,0 +1,39 @@
+package mexcportfolio
+
+import (
+	"testing"
+
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type holdings []AssetBalance
+
+func (h holdings) Snapshot() []AssetBalance { return h }
+
+func TestValuator(t *testing.T) {
+	v := NewValuator(holdings{
+		{Asset: "USDT", Free: decimal.NewFromInt(100)},
+		{Asset: "BTC", Free: decimal.NewFromInt(1)},
+		{Asset: "XYZ", Free: decimal.NewFromInt(10)},
+		{Asset: "NOPE", Free: decimal.NewFromInt(1)},
+	}, ValuatorConfig{})
+
+	v.HandleMiniTickers(&dto.PublicMiniTickersV3Api{Items: []*dto.PublicMiniTickerV3Api{
+		{Symbol: "BTCUSDT", Price: "1000"},
+		{Symbol: "XYZBTC", Price: "0.01"},
+	}})
+
+	usdt := v.Value("USDT")
+	assert.Equal(t, "1200", usdt.Total.String())
+	assert.Equal(t, []string{"NOPE"}, usdt.Unpriced)
+	require.Len(t, usdt.Assets, 3)
+	assert.Equal(t, "BTC", usdt.Assets[0].Asset)
+	assert.Equal(t, []string{"XYZ", "BTC", "USDT"}, usdt.Assets[2].Route)
+	assert.Equal(t, "100", usdt.Assets[2].Value.String())
+
+	btc := v.Value("BTC")
+	assert.Equal(t, "1.2", btc.Total.String())
+}
@@ -0,0 +1,202 @@
+package mexcrisk
+
+import (
+	"context"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"sync"
+)
+
+// Rule names reported in RiskError
+const (
+	RuleMaxNotional   = "max_notional"
+	RuleMaxPosition   = "max_position"
+	RuleMaxOpenOrders = "max_open_orders"
+	RulePriceBand     = "price_band"
+	RuleOrderRate     = "order_rate"
+	RuleInvalidOrder  = "invalid_order"
+)
+
+// RiskError is returned when order violates a rule, the order is not sent
+type RiskError struct {
+	Rule   string
+	Symbol string
+	Reason string
+}
+
+func (e *RiskError) Error() string {
+	return fmt.Sprintf("risk rule %s rejected %s order: %s", e.Rule, e.Symbol, e.Reason)
+}
+
+// Order is a parsed CreateOrderRequest
+type Order struct {
+	Symbol        string
+	Side          mexchttpmarket.Side
+	Type          mexchttpmarket.Type
+	Quantity      *decimal.Decimal
+	QuoteOrderQty *decimal.Decimal
+	Price         *decimal.Decimal
+}
+
+// Check is a pluggable pre-trade rule. Orders contain single order or the whole batch.
+// Check returns *RiskError when any of orders violates the rule.
+type Check interface {
+	Check(ctx context.Context, orders []*Order) error
+}
+
+// Committer is implemented by stateful checks, Commit is called once all checks passed and orders are about to be sent
+type Committer interface {
+	Commit(orders []*Order)
+}
+
+// OrderService is a part of market service which places orders
+type OrderService interface {
+	CreateOrder(ctx context.Context, req *mexchttpmarket.CreateOrderRequest) (*mexchttpmarket.CreateOrderResponse, error)
+	BatchOrders(ctx context.Context, req *mexchttpmarket.BatchOrdersRequest) ([]*mexchttpmarket.BatchOrderResult, error)
+}
+
+// Guard wraps order placement with pre-trade checks, checks are run in given order.
+// Checks and commits of concurrent orders are serialized, so stateful checks such as OrderRate are hard limits.
+type Guard struct {
+	next   OrderService
+	checks []Check
+	mtx    *sync.Mutex // held from the first check to the last commit
+}
+
+func NewGuard(next OrderService, checks ...Check) *Guard {
+	return &Guard{
+		next:   next,
+		checks: checks,
+		mtx:    new(sync.Mutex),
+	}
+}
+
+func (g *Guard) CreateOrder(ctx context.Context, req *mexchttpmarket.CreateOrderRequest) (*mexchttpmarket.CreateOrderResponse, error) {
+	if err := g.checkAndCommit(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return g.next.CreateOrder(ctx, req)
+}
+
+// BatchOrders rejects the whole batch if any order violates a rule
+func (g *Guard) BatchOrders(ctx context.Context, req *mexchttpmarket.BatchOrdersRequest) ([]*mexchttpmarket.BatchOrderResult, error) {
+	if err := g.checkAndCommit(ctx, req.Orders...); err != nil {
+		return nil, err
+	}
+
+	return g.next.BatchOrders(ctx, req)
+}
+
+// Check runs all checks against orders without placing them, orders aren't committed
+func (g *Guard) Check(ctx context.Context, reqs ...*mexchttpmarket.CreateOrderRequest) error {
+	_, err := g.check(ctx, reqs)
+	return err
+}
+
+func (g *Guard) checkAndCommit(ctx context.Context, reqs ...*mexchttpmarket.CreateOrderRequest) error {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	orders, err := g.check(ctx, reqs)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range g.checks {
+		if committer, ok := c.(Committer); ok {
+			committer.Commit(orders)
+		}
+	}
+	return nil
+}
+
+func (g *Guard) check(ctx context.Context, reqs []*mexchttpmarket.CreateOrderRequest) ([]*Order, error) {
+	orders := make([]*Order, 0, len(reqs))
+	for _, req := range reqs {
+		o, err := ParseOrder(req)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, o)
+	}
+
+	for _, c := range g.checks {
+		if err := c.Check(ctx, orders); err != nil {
+			return nil, err
+		}
+	}
+
+	return orders, nil
+}
+
+// ParseOrder converts string fields of request into decimals
+func ParseOrder(req *mexchttpmarket.CreateOrderRequest) (*Order, error) {
+	o := &Order{
+		Symbol: req.Symbol,
+		Side:   req.Side,
+		Type:   req.Type,
+	}
+
+	var err error
+	if o.Quantity, err = parseOptional(req.Symbol, "quantity", req.Quantity); err != nil {
+		return nil, err
+	}
+	if o.QuoteOrderQty, err = parseOptional(req.Symbol, "quoteOrderQty", req.QuoteOrderQty); err != nil {
+		return nil, err
+	}
+	if o.Price, err = parseOptional(req.Symbol, "price", req.Price); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func parseOptional(symbol, field string, v *string) (*decimal.Decimal, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	d, err := decimal.NewFromString(*v)
+	if err != nil {
+		return nil, &RiskError{Rule: RuleInvalidOrder, Symbol: symbol, Reason: fmt.Sprintf("invalid %s %q", field, *v)}
+	}
+	return &d, nil
+}
+
+// Notional returns order amount in quote asset, reference price is used for market orders by quantity
+func (o *Order) Notional(reference PriceSource) (decimal.Decimal, bool) {
+	switch {
+	case o.QuoteOrderQty != nil:
+		return *o.QuoteOrderQty, true
+	case o.Quantity == nil:
+		return decimal.Zero, false
+	case o.Price != nil:
+		return o.Quantity.Mul(*o.Price), true
+	case reference != nil:
+		price, ok := reference.Price(o.Symbol)
+		if !ok {
+			return decimal.Zero, false
+		}
+		return o.Quantity.Mul(price), true
+	default:
+		return decimal.Zero, false
+	}
+}
+
+// BaseQuantity returns order quantity in base asset, reference price is used for market orders by amount
+func (o *Order) BaseQuantity(reference PriceSource) (decimal.Decimal, bool) {
+	if o.Quantity != nil {
+		return *o.Quantity, true
+	}
+	if o.QuoteOrderQty == nil || reference == nil {
+		return decimal.Zero, false
+	}
+
+	price, ok := reference.Price(o.Symbol)
+	if !ok || !price.IsPositive() {
+		return decimal.Zero, false
+	}
+	return o.QuoteOrderQty.Div(price), true
+}
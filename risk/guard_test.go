@@ -0,0 +1,177 @@
+package mexcrisk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOrderService struct {
+	mtx     sync.Mutex
+	created int
+}
+
+func (f *fakeOrderService) CreateOrder(_ context.Context,
+	req *mexchttpmarket.CreateOrderRequest) (*mexchttpmarket.CreateOrderResponse, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.created++
+	return &mexchttpmarket.CreateOrderResponse{Symbol: req.Symbol}, nil
+}
+
+func (f *fakeOrderService) BatchOrders(_ context.Context,
+	req *mexchttpmarket.BatchOrdersRequest) ([]*mexchttpmarket.BatchOrderResult, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.created += len(req.Orders)
+	return nil, nil
+}
+
+type openOrders []mexchttpmarket.GetOrderResponse
+
+func (o openOrders) OpenOrders() []mexchttpmarket.GetOrderResponse {
+	return o
+}
+
+func limitBuy(qty, price string) *mexchttpmarket.CreateOrderRequest {
+	return &mexchttpmarket.CreateOrderRequest{
+		Symbol:   "BTCUSDT",
+		Side:     mexchttpmarket.SideBuy,
+		Type:     mexchttpmarket.TypeLimit,
+		Quantity: &qty,
+		Price:    &price,
+	}
+}
+
+func assertRule(t *testing.T, err error, rule string) {
+	t.Helper()
+
+	var riskErr *RiskError
+	require.True(t, errors.As(err, &riskErr), "expected RiskError, got %v", err)
+	assert.Equal(t, rule, riskErr.Rule)
+}
+
+func TestGuard(t *testing.T) {
+	prices := NewPrices()
+	prices.Set("BTCUSDT", decimal.NewFromInt(100))
+
+	orders := openOrders{
+		{Symbol: "BTCUSDT", Side: mexchttpmarket.SideBuy, OrigQty: decimal.NewFromInt(2), ExecutedQty: decimal.NewFromInt(1)},
+	}
+
+	next := &fakeOrderService{}
+	guard := NewGuard(next,
+		&MaxNotional{Limit: decimal.NewFromInt(1000), Prices: prices},
+		&PriceBand{MaxDeviation: decimal.RequireFromString("0.05"), Prices: prices},
+		&MaxOpenOrders{Limit: 2, Orders: orders},
+		&MaxPosition{
+			Limits:   map[string]decimal.Decimal{"BTC": decimal.NewFromInt(5)},
+			Assets:   BaseAssets{"BTCUSDT": "BTC"},
+			Balances: Balances{"BTC": decimal.NewFromInt(2)},
+			Orders:   orders,
+		},
+		NewOrderRate(3),
+	)
+	ctx := context.Background()
+
+	_, err := guard.CreateOrder(ctx, limitBuy("1", "101"))
+	require.NoError(t, err)
+
+	_, err = guard.CreateOrder(ctx, limitBuy("20", "100"))
+	assertRule(t, err, RuleMaxNotional)
+
+	_, err = guard.CreateOrder(ctx, limitBuy("1", "110"))
+	assertRule(t, err, RulePriceBand)
+
+	_, err = guard.BatchOrders(ctx, &mexchttpmarket.BatchOrdersRequest{
+		Orders: []*mexchttpmarket.CreateOrderRequest{limitBuy("1", "100"), limitBuy("1", "100")},
+	})
+	assertRule(t, err, RuleMaxOpenOrders)
+
+	_, err = guard.CreateOrder(ctx, limitBuy("2.5", "100"))
+	assertRule(t, err, RuleMaxPosition)
+
+	_, err = guard.CreateOrder(ctx, limitBuy("1", "100"))
+	require.NoError(t, err)
+	_, err = guard.CreateOrder(ctx, limitBuy("1", "100"))
+	require.NoError(t, err)
+	_, err = guard.CreateOrder(ctx, limitBuy("1", "100"))
+	assertRule(t, err, RuleOrderRate)
+
+	assert.Equal(t, 3, next.created)
+}
+
+func TestGuard_RateCountsAcceptedOrders(t *testing.T) {
+	next := &fakeOrderService{}
+	guard := NewGuard(next,
+		NewOrderRate(2),
+		&MaxOpenOrders{Limit: 1},
+		&MaxNotional{Limit: decimal.NewFromInt(1000)},
+	)
+	ctx := context.Background()
+
+	_, err := guard.CreateOrder(ctx, limitBuy("20", "100"))
+	assertRule(t, err, RuleMaxNotional)
+	_, err = guard.BatchOrders(ctx, &mexchttpmarket.BatchOrdersRequest{
+		Orders: []*mexchttpmarket.CreateOrderRequest{limitBuy("1", "100"), limitBuy("1", "100")},
+	})
+	assertRule(t, err, RuleMaxOpenOrders)
+	require.NoError(t, guard.Check(ctx, limitBuy("1", "100")))
+
+	// rejected and checked only orders don't use rate budget
+	_, err = guard.CreateOrder(ctx, limitBuy("1", "100"))
+	require.NoError(t, err)
+	_, err = guard.CreateOrder(ctx, limitBuy("1", "100"))
+	require.NoError(t, err)
+	_, err = guard.CreateOrder(ctx, limitBuy("1", "100"))
+	assertRule(t, err, RuleOrderRate)
+
+	assert.Equal(t, 2, next.created)
+}
+
+// slowCheck widens the window between rate check and commit
+type slowCheck struct{}
+
+func (slowCheck) Check(context.Context, []*Order) error {
+	time.Sleep(time.Millisecond)
+	return nil
+}
+
+func TestGuard_RateIsHardLimitUnderConcurrency(t *testing.T) {
+	const limit, workers = 5, 50
+
+	next := &fakeOrderService{}
+	guard := NewGuard(next, NewOrderRate(limit), slowCheck{})
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if i%2 == 0 {
+				_, _ = guard.CreateOrder(ctx, limitBuy("1", "100"))
+				return
+			}
+			_, _ = guard.BatchOrders(ctx, &mexchttpmarket.BatchOrdersRequest{
+				Orders: []*mexchttpmarket.CreateOrderRequest{limitBuy("1", "100"), limitBuy("1", "100")},
+			})
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	assert.LessOrEqual(t, next.created, limit)
+	assert.Positive(t, next.created)
+}
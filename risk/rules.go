@@ -0,0 +1,210 @@
+package mexcrisk
+
+import (
+	"context"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"sync"
+	"time"
+)
+
+// MaxNotional limits amount of every order in quote asset.
+// Prices are used for market orders by quantity, such orders are rejected if price is unknown.
+type MaxNotional struct {
+	Limit  decimal.Decimal
+	Prices PriceSource
+}
+
+func (r *MaxNotional) Check(_ context.Context, orders []*Order) error {
+	for _, o := range orders {
+		notional, ok := o.Notional(r.Prices)
+		if !ok {
+			return &RiskError{Rule: RuleMaxNotional, Symbol: o.Symbol, Reason: "notional can't be estimated"}
+		}
+		if notional.GreaterThan(r.Limit) {
+			return &RiskError{Rule: RuleMaxNotional, Symbol: o.Symbol,
+				Reason: fmt.Sprintf("notional %s exceeds limit %s", notional, r.Limit)}
+		}
+	}
+
+	return nil
+}
+
+// MaxPosition limits holdings of base assets after all buy orders are filled.
+// Projected position is balance plus remaining quantity of open buy orders plus new buy orders.
+// Balances and Orders are optional, missing sources are treated as zero.
+type MaxPosition struct {
+	Limits   map[string]decimal.Decimal // base asset -> max holdings
+	Assets   BaseAssets
+	Balances BalanceSource
+	Orders   OpenOrdersSource
+	Prices   PriceSource
+}
+
+func (r *MaxPosition) Check(_ context.Context, orders []*Order) error {
+	buys := make(map[string]decimal.Decimal)
+	symbols := make(map[string]string)
+	for _, o := range orders {
+		if o.Side != mexchttpmarket.SideBuy {
+			continue
+		}
+
+		asset, ok := r.Assets[o.Symbol]
+		if !ok {
+			return &RiskError{Rule: RuleMaxPosition, Symbol: o.Symbol, Reason: "base asset is unknown"}
+		}
+		if _, limited := r.Limits[asset]; !limited {
+			continue
+		}
+
+		qty, ok := o.BaseQuantity(r.Prices)
+		if !ok {
+			return &RiskError{Rule: RuleMaxPosition, Symbol: o.Symbol, Reason: "quantity can't be estimated"}
+		}
+		buys[asset] = buys[asset].Add(qty)
+		symbols[asset] = o.Symbol
+	}
+
+	if len(buys) == 0 {
+		return nil
+	}
+
+	if r.Orders != nil {
+		for _, o := range r.Orders.OpenOrders() {
+			asset := r.Assets[o.Symbol]
+			if _, ok := buys[asset]; ok && o.Side == mexchttpmarket.SideBuy {
+				buys[asset] = buys[asset].Add(o.OrigQty.Sub(o.ExecutedQty))
+			}
+		}
+	}
+
+	for asset, qty := range buys {
+		position := qty
+		if r.Balances != nil {
+			if balance, ok := r.Balances.Balance(asset); ok {
+				position = position.Add(balance)
+			}
+		}
+
+		if limit := r.Limits[asset]; position.GreaterThan(limit) {
+			return &RiskError{Rule: RuleMaxPosition, Symbol: symbols[asset],
+				Reason: fmt.Sprintf("projected %s position %s exceeds limit %s", asset, position, limit)}
+		}
+	}
+
+	return nil
+}
+
+// MaxOpenOrders limits number of open orders per symbol including new ones.
+// Orders is optional, only new orders are counted without it.
+type MaxOpenOrders struct {
+	Limit  int
+	Orders OpenOrdersSource
+}
+
+func (r *MaxOpenOrders) Check(_ context.Context, orders []*Order) error {
+	count := make(map[string]int)
+	for _, o := range orders {
+		count[o.Symbol]++
+	}
+
+	if r.Orders != nil {
+		for _, o := range r.Orders.OpenOrders() {
+			if _, ok := count[o.Symbol]; ok {
+				count[o.Symbol]++
+			}
+		}
+	}
+
+	for symbol, n := range count {
+		if n > r.Limit {
+			return &RiskError{Rule: RuleMaxOpenOrders, Symbol: symbol,
+				Reason: fmt.Sprintf("%d open orders exceed limit %d", n, r.Limit)}
+		}
+	}
+
+	return nil
+}
+
+// PriceBand rejects limit orders priced too far from reference price,
+// MaxDeviation is a fraction, e.g. 0.05 allows 5% deviation. Market orders are not checked.
+type PriceBand struct {
+	MaxDeviation decimal.Decimal
+	Prices       PriceSource
+}
+
+func (r *PriceBand) Check(_ context.Context, orders []*Order) error {
+	for _, o := range orders {
+		if o.Price == nil {
+			continue
+		}
+
+		reference, ok := r.Prices.Price(o.Symbol)
+		if !ok || !reference.IsPositive() {
+			return &RiskError{Rule: RulePriceBand, Symbol: o.Symbol, Reason: "reference price is unavailable"}
+		}
+
+		deviation := o.Price.Sub(reference).Abs().Div(reference)
+		if deviation.GreaterThan(r.MaxDeviation) {
+			return &RiskError{Rule: RulePriceBand, Symbol: o.Symbol,
+				Reason: fmt.Sprintf("price %s deviates from reference %s by %s", o.Price, reference, deviation.StringFixed(4))}
+		}
+	}
+
+	return nil
+}
+
+// OrderRate limits number of orders sent per second, every order of batch is counted.
+// Orders are counted on Commit, so orders rejected by other checks don't use the budget.
+// Guard serializes Check and Commit, so OrderRate shouldn't be shared between guards.
+type OrderRate struct {
+	limit int
+	mtx   *sync.Mutex
+	sent  []time.Time
+}
+
+func NewOrderRate(perSecond int) *OrderRate {
+	return &OrderRate{
+		limit: perSecond,
+		mtx:   new(sync.Mutex),
+	}
+}
+
+func (r *OrderRate) Check(_ context.Context, orders []*Order) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.expire(time.Now())
+	if len(r.sent)+len(orders) > r.limit {
+		symbol := ""
+		if len(orders) > 0 {
+			symbol = orders[0].Symbol
+		}
+		return &RiskError{Rule: RuleOrderRate, Symbol: symbol,
+			Reason: fmt.Sprintf("more than %d orders per second", r.limit)}
+	}
+
+	return nil
+}
+
+// Commit counts orders as sent
+func (r *OrderRate) Commit(orders []*Order) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	now := time.Now()
+	for range orders {
+		r.sent = append(r.sent, now)
+	}
+}
+
+func (r *OrderRate) expire(now time.Time) {
+	from := now.Add(-time.Second)
+
+	i := 0
+	for i < len(r.sent) && !r.sent[i].After(from) {
+		i++
+	}
+	r.sent = r.sent[i:]
+}
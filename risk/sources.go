@@ -0,0 +1,78 @@
+package mexcrisk
+
+import (
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"sync"
+)
+
+// PriceSource provides reference price of symbol, e.g. last trade or book mid
+type PriceSource interface {
+	Price(symbol string) (decimal.Decimal, bool)
+}
+
+// BalanceSource provides total (free + locked) balance of asset
+type BalanceSource interface {
+	Balance(asset string) (decimal.Decimal, bool)
+}
+
+// OpenOrdersSource provides live open orders, e.g. mexcoms.Manager
+type OpenOrdersSource interface {
+	OpenOrders() []mexchttpmarket.GetOrderResponse
+}
+
+// Prices is a thread-safe PriceSource updated by caller, e.g. from deals or book ticker stream
+type Prices struct {
+	mtx    *sync.RWMutex
+	prices map[string]decimal.Decimal
+}
+
+func NewPrices() *Prices {
+	return &Prices{
+		mtx:    new(sync.RWMutex),
+		prices: make(map[string]decimal.Decimal),
+	}
+}
+
+func (p *Prices) Set(symbol string, price decimal.Decimal) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.prices[symbol] = price
+}
+
+func (p *Prices) Price(symbol string) (decimal.Decimal, bool) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	price, ok := p.prices[symbol]
+	return price, ok
+}
+
+// Balances is a static BalanceSource
+type Balances map[string]decimal.Decimal
+
+// BalancesFromAccount builds total balances from account information
+func BalancesFromAccount(info *mexchttpmarket.AccountInformationResponse) Balances {
+	b := make(Balances, len(info.Balances))
+	for _, balance := range info.Balances {
+		b[balance.Asset] = balance.Total()
+	}
+	return b
+}
+
+func (b Balances) Balance(asset string) (decimal.Decimal, bool) {
+	v, ok := b[asset]
+	return v, ok
+}
+
+// BaseAssets maps symbol to its base asset, e.g. BTCUSDT -> BTC
+type BaseAssets map[string]string
+
+func BaseAssetsFromExchangeInfo(info *mexchttpmarket.ExchangeInfo) BaseAssets {
+	assets := make(BaseAssets, len(info.Symbols))
+	for i := range info.Symbols {
+		assets[info.Symbols[i].Symbol] = info.Symbols[i].BaseAsset
+	}
+	return assets
+}
@@ -0,0 +1,446 @@
+package mexcsnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/ledger"
+	"github.com/shopspring/decimal"
+	"slices"
+	"sort"
+	"time"
+)
+
+const transferHistoryPageSize = 100
+
+type MovementKind string
+
+const (
+	MovementTrade      MovementKind = "TRADE"
+	MovementFee        MovementKind = "FEE"
+	MovementDeposit    MovementKind = "DEPOSIT"
+	MovementWithdrawal MovementKind = "WITHDRAWAL"
+	MovementTransfer   MovementKind = "TRANSFER"
+)
+
+// Movement is a signed balance change of asset found in history
+type Movement struct {
+	Kind   MovementKind    `json:"kind"`
+	Ref    string          `json:"ref"` // trade, deposit tx, withdrawal or transfer id
+	Asset  string          `json:"asset"`
+	Amount decimal.Decimal `json:"amount"`
+	Time   int64           `json:"time"`
+}
+
+// AssetChange of total balance between two snapshots.
+// Unexplained is a part of change not covered by movements, it's the whole change before explanation.
+type AssetChange struct {
+	Asset       string          `json:"asset"`
+	Before      decimal.Decimal `json:"before"`
+	After       decimal.Decimal `json:"after"`
+	Change      decimal.Decimal `json:"change"`
+	Explained   decimal.Decimal `json:"explained"`
+	Unexplained decimal.Decimal `json:"unexplained"`
+	Movements   []Movement      `json:"movements,omitempty"`
+}
+
+type Diff struct {
+	From    time.Time     `json:"from"`
+	To      time.Time     `json:"to"`
+	Changes []AssetChange `json:"changes"` // ordered by asset
+}
+
+// Compare returns balance changes between snapshots, unchanged assets are omitted
+func Compare(prev, cur *Snapshot) *Diff {
+	before := make(map[string]decimal.Decimal, len(prev.Balances))
+	for _, b := range prev.Balances {
+		before[b.Asset] = b.Total()
+	}
+	after := make(map[string]decimal.Decimal, len(cur.Balances))
+	for _, b := range cur.Balances {
+		after[b.Asset] = b.Total()
+	}
+
+	diff := &Diff{From: prev.TakenAt, To: cur.TakenAt}
+	for asset := range union(before, after) {
+		change := after[asset].Sub(before[asset])
+		if change.IsZero() {
+			continue
+		}
+		diff.Changes = append(diff.Changes, AssetChange{
+			Asset:       asset,
+			Before:      before[asset],
+			After:       after[asset],
+			Change:      change,
+			Unexplained: change,
+		})
+	}
+	sort.Slice(diff.Changes, func(i, j int) bool { return diff.Changes[i].Asset < diff.Changes[j].Asset })
+
+	return diff
+}
+
+func union(a, b map[string]decimal.Decimal) map[string]struct{} {
+	res := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		res[k] = struct{}{}
+	}
+	for k := range b {
+		res[k] = struct{}{}
+	}
+	return res
+}
+
+// HistoryService is a part of market service which explains balance changes
+type HistoryService interface {
+	WalkAccountTrades(ctx context.Context, req mexchttpmarket.AccountTradesRangeRequest, fn func([]*mexchttpmarket.GetAccountTradeListResponse) error) error
+	GetDepositHistory(ctx context.Context, req mexchttpmarket.DepositHistoryRequest) ([]mexchttpmarket.DepositRecord, error)
+	WalkWithdrawHistory(ctx context.Context, r mexchttpmarket.WithdrawHistoryRange, fn func([]mexchttpmarket.WithdrawRecord) error) error
+	GetTransferHistory(ctx context.Context, req mexchttpmarket.OwnTransferHistoryRequest) (*mexchttpmarket.OwnTransferHistoryResponse, error)
+	WalkInternalTransfers(ctx context.Context, r mexchttpmarket.TransferHistoryRange, fn func([]mexchttpmarket.InternalTransferRecord) error) error
+	WalkUniversalTransfers(ctx context.Context, r mexchttpmarket.TransferHistoryRange, fn func([]mexchttpmarket.TransferRecord) error) error
+}
+
+type ExplainerConfig struct {
+	// Identities of snapshot account in internal and universal transfer records, e.g. email, UID or sub-account name.
+	// Empty account of a record is the master one, so master snapshot matches it without identities.
+	Identities []string
+	// SubAccount is set for sub-account snapshot, records with empty account aren't its own then
+	SubAccount         bool
+	UniversalTransfers bool // walk universal transfers, requires master account key
+}
+
+// Explainer matches balance changes with trade, deposit, withdrawal and transfer histories
+type Explainer struct {
+	rest   HistoryService
+	assets map[string]mexcledger.SymbolAssets
+	cfg    ExplainerConfig
+}
+
+// NewExplainer assets maps traded symbols to their base and quote asset, see mexcledger.AssetsFromExchangeInfo
+func NewExplainer(rest HistoryService, assets map[string]mexcledger.SymbolAssets, cfg ExplainerConfig) *Explainer {
+	return &Explainer{
+		rest:   rest,
+		assets: assets,
+		cfg:    cfg,
+	}
+}
+
+// Symbols returns traded symbols in order
+func (e *Explainer) Symbols() []string {
+	symbols := make([]string, 0, len(e.assets))
+	for symbol := range e.assets {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// Explain adds movements between diff.From and diff.To to changes of their assets.
+// Movements of assets which balance didn't change are added too, they net out in Explained.
+func (e *Explainer) Explain(ctx context.Context, diff *Diff) error {
+	movements, err := e.Movements(ctx, diff.From, diff.To)
+	if err != nil {
+		return err
+	}
+
+	index := make(map[string]int, len(diff.Changes))
+	for i := range diff.Changes {
+		index[diff.Changes[i].Asset] = i
+	}
+
+	for _, m := range movements {
+		i, ok := index[m.Asset]
+		if !ok {
+			diff.Changes = append(diff.Changes, AssetChange{Asset: m.Asset})
+			i = len(diff.Changes) - 1
+			index[m.Asset] = i
+		}
+		change := &diff.Changes[i]
+		change.Movements = append(change.Movements, m)
+		change.Explained = change.Explained.Add(m.Amount)
+		change.Unexplained = change.Change.Sub(change.Explained)
+	}
+	sort.Slice(diff.Changes, func(i, j int) bool { return diff.Changes[i].Asset < diff.Changes[j].Asset })
+
+	return nil
+}
+
+// Movements returns balance changes of spot account found in histories between from and to
+func (e *Explainer) Movements(ctx context.Context, from, to time.Time) ([]Movement, error) {
+	var movements []Movement
+
+	trades, err := e.tradeMovements(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	movements = append(movements, trades...)
+
+	deposits, err := e.depositMovements(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	movements = append(movements, deposits...)
+
+	withdrawals, err := e.withdrawalMovements(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	movements = append(movements, withdrawals...)
+
+	transfers, err := e.transferMovements(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	movements = append(movements, transfers...)
+
+	internal, err := e.internalTransferMovements(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	movements = append(movements, internal...)
+
+	if e.cfg.UniversalTransfers {
+		universal, err := e.universalTransferMovements(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, universal...)
+	}
+
+	sort.SliceStable(movements, func(i, j int) bool { return movements[i].Time < movements[j].Time })
+
+	return movements, nil
+}
+
+func (e *Explainer) tradeMovements(ctx context.Context, from, to time.Time) ([]Movement, error) {
+	var movements []Movement
+	for _, symbol := range e.Symbols() {
+		assets := e.assets[symbol]
+		err := e.rest.WalkAccountTrades(ctx, mexchttpmarket.AccountTradesRangeRequest{Symbol: symbol, StartTime: from, EndTime: to},
+			func(trades []*mexchttpmarket.GetAccountTradeListResponse) error {
+				for _, t := range trades {
+					base, quote := t.Qty, t.QuoteQty.Neg()
+					if !t.IsBuyer {
+						base, quote = base.Neg(), quote.Neg()
+					}
+					movements = append(movements,
+						Movement{Kind: MovementTrade, Ref: t.ID, Asset: assets.Base, Amount: base, Time: t.Time},
+						Movement{Kind: MovementTrade, Ref: t.ID, Asset: assets.Quote, Amount: quote, Time: t.Time},
+					)
+					if t.Commission.IsPositive() && t.CommissionAsset != "" {
+						movements = append(movements,
+							Movement{Kind: MovementFee, Ref: t.ID, Asset: t.CommissionAsset, Amount: t.Commission.Neg(), Time: t.Time})
+					}
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, fmt.Errorf("trades %s: %w", symbol, err)
+		}
+	}
+
+	return movements, nil
+}
+
+func (e *Explainer) depositMovements(ctx context.Context, from, to time.Time) ([]Movement, error) {
+	startTime, endTime := from.UnixMilli(), to.UnixMilli()
+	deposits, err := e.rest.GetDepositHistory(ctx, mexchttpmarket.DepositHistoryRequest{
+		StartTime: &startTime,
+		EndTime:   &endTime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("deposit history: %w", err)
+	}
+
+	var movements []Movement
+	for _, d := range deposits {
+		if !d.Status.IsCredited() || d.InsertTime < startTime || d.InsertTime >= endTime {
+			continue
+		}
+		movements = append(movements, Movement{Kind: MovementDeposit, Ref: d.TxID, Asset: d.Coin, Amount: d.Amount, Time: d.InsertTime})
+	}
+
+	return movements, nil
+}
+
+// withdrawalMovements debits amount and fee of every withdrawal applied in range, failed and cancelled ones are skipped
+func (e *Explainer) withdrawalMovements(ctx context.Context, from, to time.Time) ([]Movement, error) {
+	var movements []Movement
+	err := e.rest.WalkWithdrawHistory(ctx, mexchttpmarket.WithdrawHistoryRange{Start: from, End: to},
+		func(withdrawals []mexchttpmarket.WithdrawRecord) error {
+			for _, w := range withdrawals {
+				if w.Status == mexchttpmarket.WithdrawStatusFailed || w.Status == mexchttpmarket.WithdrawStatusCancel ||
+					w.ApplyTime < from.UnixMilli() || w.ApplyTime >= to.UnixMilli() {
+					continue
+				}
+
+				amount, err := parseAmount(w.Amount)
+				if err != nil {
+					return fmt.Errorf("withdrawal %s amount: %w", w.ID, err)
+				}
+				fee, err := parseAmount(w.TransactionFee)
+				if err != nil {
+					return fmt.Errorf("withdrawal %s fee: %w", w.ID, err)
+				}
+
+				movements = append(movements, Movement{Kind: MovementWithdrawal, Ref: w.ID, Asset: w.Coin, Amount: amount.Neg(), Time: w.ApplyTime})
+				if fee.IsPositive() {
+					movements = append(movements, Movement{Kind: MovementFee, Ref: w.ID, Asset: w.Coin, Amount: fee.Neg(), Time: w.ApplyTime})
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("withdraw history: %w", err)
+	}
+
+	return movements, nil
+}
+
+// transferMovements returns transfers between spot and futures accounts, spot is credited or debited
+func (e *Explainer) transferMovements(ctx context.Context, from, to time.Time) ([]Movement, error) {
+	var movements []Movement
+
+	directions := [][2]mexchttpmarket.AccountType{
+		{mexchttpmarket.AccountTypeSpot, mexchttpmarket.AccountTypeFutures},
+		{mexchttpmarket.AccountTypeFutures, mexchttpmarket.AccountTypeSpot},
+	}
+	for _, direction := range directions {
+		size := transferHistoryPageSize
+		for page := 1; ; page++ {
+			current := page
+			resp, err := e.rest.GetTransferHistory(ctx, mexchttpmarket.OwnTransferHistoryRequest{
+				FromAccountType: direction[0],
+				ToAccountType:   direction[1],
+				StartTime:       &from,
+				EndTime:         &to,
+				Page:            &current,
+				Size:            &size,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("transfer history %s to %s: %w", direction[0], direction[1], err)
+			}
+
+			for _, t := range resp.Rows {
+				if t.Status != "SUCCESS" || t.Timestamp < from.UnixMilli() || t.Timestamp >= to.UnixMilli() {
+					continue
+				}
+				amount, err := parseAmount(t.Amount)
+				if err != nil {
+					return nil, fmt.Errorf("transfer %s amount: %w", t.TranId, err)
+				}
+				if direction[0] == mexchttpmarket.AccountTypeSpot {
+					amount = amount.Neg()
+				}
+				movements = append(movements, Movement{Kind: MovementTransfer, Ref: t.TranId, Asset: t.Asset, Amount: amount, Time: t.Timestamp})
+			}
+
+			if len(resp.Rows) < size || page*size >= int(resp.Total) {
+				break
+			}
+		}
+	}
+
+	return movements, nil
+}
+
+// internalTransferMovements returns transfers to and from other users, spot of own side is credited or debited
+func (e *Explainer) internalTransferMovements(ctx context.Context, from, to time.Time) ([]Movement, error) {
+	var movements []Movement
+	err := e.rest.WalkInternalTransfers(ctx, mexchttpmarket.TransferHistoryRange{Start: from, End: to},
+		func(transfers []mexchttpmarket.InternalTransferRecord) error {
+			for _, t := range transfers {
+				if t.Status != "SUCCESS" || t.Timestamp < from.UnixMilli() || t.Timestamp >= to.UnixMilli() {
+					continue
+				}
+				sign, ok := e.spotSign(t.FromAccount, mexchttpmarket.AccountType(t.FromAccountType),
+					t.ToAccount, mexchttpmarket.AccountType(t.ToAccountType))
+				if !ok {
+					continue
+				}
+				amount, err := parseAmount(t.Amount)
+				if err != nil {
+					return fmt.Errorf("internal transfer %s amount: %w", t.TranId, err)
+				}
+				movements = append(movements, Movement{Kind: MovementTransfer, Ref: t.TranId, Asset: t.Asset,
+					Amount: amount.Mul(sign), Time: t.Timestamp})
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("internal transfers: %w", err)
+	}
+
+	return movements, nil
+}
+
+// universalTransferMovements returns transfers between master and sub-accounts which touch spot of snapshot account
+func (e *Explainer) universalTransferMovements(ctx context.Context, from, to time.Time) ([]Movement, error) {
+	var movements []Movement
+
+	directions := [][2]mexchttpmarket.AccountType{
+		{mexchttpmarket.AccountTypeSpot, mexchttpmarket.AccountTypeSpot},
+		{mexchttpmarket.AccountTypeSpot, mexchttpmarket.AccountTypeFutures},
+		{mexchttpmarket.AccountTypeFutures, mexchttpmarket.AccountTypeSpot},
+	}
+	for _, direction := range directions {
+		err := e.rest.WalkUniversalTransfers(ctx, mexchttpmarket.TransferHistoryRange{
+			Start:           from,
+			End:             to,
+			FromAccountType: direction[0],
+			ToAccountType:   direction[1],
+		}, func(transfers []mexchttpmarket.TransferRecord) error {
+			for _, t := range transfers {
+				// transfers within the same account are in own transfer history
+				if t.Status != "SUCCESS" || t.FromAccount == t.ToAccount ||
+					t.Timestamp < from.UnixMilli() || t.Timestamp >= to.UnixMilli() {
+					continue
+				}
+				sign, ok := e.spotSign(t.FromAccount, t.FromAccountType, t.ToAccount, t.ToAccountType)
+				if !ok {
+					continue
+				}
+				amount, err := parseAmount(t.Amount)
+				if err != nil {
+					return fmt.Errorf("universal transfer %s amount: %w", t.TranId, err)
+				}
+				movements = append(movements, Movement{Kind: MovementTransfer, Ref: t.TranId, Asset: t.Asset,
+					Amount: amount.Mul(sign), Time: t.Timestamp})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("universal transfers %s to %s: %w", direction[0], direction[1], err)
+		}
+	}
+
+	return movements, nil
+}
+
+// spotSign returns -1 if transfer leaves spot of snapshot account and 1 if it arrives there
+func (e *Explainer) spotSign(fromAccount string, fromType mexchttpmarket.AccountType,
+	toAccount string, toType mexchttpmarket.AccountType) (decimal.Decimal, bool) {
+	switch {
+	case fromType == mexchttpmarket.AccountTypeSpot && e.own(fromAccount):
+		return decimal.NewFromInt(-1), true
+	case toType == mexchttpmarket.AccountTypeSpot && e.own(toAccount):
+		return decimal.NewFromInt(1), true
+	}
+	return decimal.Zero, false
+}
+
+func (e *Explainer) own(account string) bool {
+	if account == "" {
+		return !e.cfg.SubAccount
+	}
+	return slices.Contains(e.cfg.Identities, account)
+}
+
+func parseAmount(s string) (decimal.Decimal, error) {
+	if s == "" {
+		return decimal.Zero, errors.New("empty amount")
+	}
+	return decimal.NewFromString(s)
+}
@@ -0,0 +1,174 @@
+package mexcsnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"time"
+)
+
+const DefaultPendingLookback = 7 * 24 * time.Hour
+
+// AccountService is a part of market service which is captured in snapshot
+type AccountService interface {
+	GetAccountInformation(ctx context.Context, req mexchttpmarket.AccountInformationRequest) (*mexchttpmarket.AccountInformationResponse, error)
+	OpenOrders(ctx context.Context, req *mexchttpmarket.OpenOrdersRequest) ([]*mexchttpmarket.GetOrderResponse, error)
+	WalkWithdrawHistory(ctx context.Context, r mexchttpmarket.WithdrawHistoryRange, fn func([]mexchttpmarket.WithdrawRecord) error) error
+}
+
+// Snapshot of spot account state, OpenOrders are captured for OpenOrderSymbols only
+type Snapshot struct {
+	Account            string                             `json:"account"`
+	TakenAt            time.Time                          `json:"takenAt"`
+	Balances           []mexchttpmarket.Balance           `json:"balances"`
+	OpenOrderSymbols   []string                           `json:"openOrderSymbols"`
+	OpenOrders         []*mexchttpmarket.GetOrderResponse `json:"openOrders"`
+	PendingWithdrawals []mexchttpmarket.WithdrawRecord    `json:"pendingWithdrawals"`
+}
+
+// Entry is written to sink, Diff is nil for the first snapshot
+type Entry struct {
+	Snapshot *Snapshot `json:"snapshot"`
+	Diff     *Diff     `json:"diff,omitempty"`
+}
+
+type Sink interface {
+	Write(ctx context.Context, entry *Entry) error
+}
+
+type RecorderConfig struct {
+	Account         string        // label of account, e.g. sub-account name
+	Symbols         []string      // symbols to capture open orders of, symbols of Explainer if empty
+	PendingLookback time.Duration // withdrawals applied within lookback are checked, DefaultPendingLookback if zero
+	Explainer       *Explainer    // optional, diffs are explained by histories if set
+	OnEntry         func(*Entry)  // optional, called after entry is written
+}
+
+// Recorder captures account snapshots and diffs them with the previous one
+type Recorder struct {
+	rest AccountService
+	sink Sink
+	cfg  RecorderConfig
+
+	previous *Snapshot
+}
+
+func NewRecorder(rest AccountService, sink Sink, cfg RecorderConfig) *Recorder {
+	if cfg.PendingLookback <= 0 {
+		cfg.PendingLookback = DefaultPendingLookback
+	}
+
+	return &Recorder{
+		rest: rest,
+		sink: sink,
+		cfg:  cfg,
+	}
+}
+
+// SetPrevious sets snapshot the next one is diffed with, e.g. the last one read from sink after restart
+func (r *Recorder) SetPrevious(s *Snapshot) {
+	r.previous = s
+}
+
+// Capture takes snapshot without writing it. TakenAt is set once balances are received,
+// so histories up to it cover every change included in balances.
+func (r *Recorder) Capture(ctx context.Context) (*Snapshot, error) {
+	account, err := r.rest.GetAccountInformation(ctx, mexchttpmarket.AccountInformationRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("account information: %w", err)
+	}
+	takenAt := time.Now()
+
+	s := &Snapshot{
+		Account:          r.cfg.Account,
+		TakenAt:          takenAt,
+		Balances:         account.Balances,
+		OpenOrderSymbols: r.symbols(),
+	}
+
+	for _, symbol := range s.OpenOrderSymbols {
+		orders, err := r.rest.OpenOrders(ctx, &mexchttpmarket.OpenOrdersRequest{Symbol: symbol})
+		if err != nil {
+			return nil, fmt.Errorf("open orders %s: %w", symbol, err)
+		}
+		s.OpenOrders = append(s.OpenOrders, orders...)
+	}
+
+	err = r.rest.WalkWithdrawHistory(ctx, mexchttpmarket.WithdrawHistoryRange{
+		Start: takenAt.Add(-r.cfg.PendingLookback),
+		End:   takenAt,
+	}, func(withdrawals []mexchttpmarket.WithdrawRecord) error {
+		for _, w := range withdrawals {
+			if !w.Status.IsFinal() {
+				s.PendingWithdrawals = append(s.PendingWithdrawals, w)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("withdraw history: %w", err)
+	}
+
+	return s, nil
+}
+
+// symbols returns symbols to capture open orders of, empty list is recorded as such in snapshot
+func (r *Recorder) symbols() []string {
+	switch {
+	case len(r.cfg.Symbols) > 0:
+		return r.cfg.Symbols
+	case r.cfg.Explainer != nil:
+		return r.cfg.Explainer.Symbols()
+	default:
+		return []string{}
+	}
+}
+
+// Record captures snapshot, diffs it with the previous one and writes entry to sink.
+// Explanation errors don't prevent writing, they are returned with the entry.
+func (r *Recorder) Record(ctx context.Context) (*Entry, error) {
+	s, err := r.Capture(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &Entry{Snapshot: s}
+	var explainErr error
+	if r.previous != nil {
+		entry.Diff = Compare(r.previous, s)
+		if r.cfg.Explainer != nil {
+			explainErr = r.cfg.Explainer.Explain(ctx, entry.Diff)
+		}
+	}
+
+	if err := r.sink.Write(ctx, entry); err != nil {
+		return nil, fmt.Errorf("write snapshot: %w", err)
+	}
+	r.previous = s
+
+	if r.cfg.OnEntry != nil {
+		r.cfg.OnEntry(entry)
+	}
+
+	return entry, explainErr
+}
+
+// Run records snapshot at every multiple of interval since zero time (January 1, year 1, UTC),
+// e.g. at 00:00 UTC for 24h interval and at full hours for 1h interval
+func (r *Recorder) Run(ctx context.Context, interval time.Duration, errCallback func(error)) {
+	for {
+		now := time.Now()
+		timer := time.NewTimer(now.Truncate(interval).Add(interval).Sub(now))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			if _, err := r.Record(ctx); err != nil && errCallback != nil && !errors.Is(err, context.Canceled) {
+				errCallback(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package mexcsnapshot
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// JSONLinesSink appends entries to file, one JSON object per line
+type JSONLinesSink struct {
+	mtx  *sync.Mutex
+	file *os.File
+}
+
+func NewJSONLinesSink(path string) (*JSONLinesSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONLinesSink{
+		mtx:  new(sync.Mutex),
+		file: file,
+	}, nil
+}
+
+func (s *JSONLinesSink) Write(_ context.Context, entry *Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+func (s *JSONLinesSink) Close() error {
+	return s.file.Close()
+}
+
+// LastSnapshot reads the last snapshot of JSON-lines file, nil if file is empty or missing
+func LastSnapshot(path string) (*Snapshot, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var last *Snapshot
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 1 {
+			var entry Entry
+			if jErr := json.Unmarshal(line, &entry); jErr != nil {
+				return nil, fmt.Errorf("parse snapshot: %w", jErr)
+			}
+			last = entry.Snapshot
+		}
+		if errors.Is(err, io.EOF) {
+			return last, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package mexcsnapshot
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/kattana-io/mexc-golang-sdk/ledger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeService struct {
+	balances    []mexchttpmarket.Balance
+	orders      []*mexchttpmarket.GetOrderResponse
+	withdrawals []mexchttpmarket.WithdrawRecord
+	trades      []*mexchttpmarket.GetAccountTradeListResponse
+	deposits    []mexchttpmarket.DepositRecord
+	transfers   map[mexchttpmarket.AccountType][]mexchttpmarket.OwnTransferRecord // by from account type
+	internal    []mexchttpmarket.InternalTransferRecord
+	universal   []mexchttpmarket.TransferRecord
+}
+
+func (f *fakeService) GetAccountInformation(_ context.Context,
+	_ mexchttpmarket.AccountInformationRequest) (*mexchttpmarket.AccountInformationResponse, error) {
+	return &mexchttpmarket.AccountInformationResponse{Balances: f.balances}, nil
+}
+
+func (f *fakeService) OpenOrders(_ context.Context,
+	_ *mexchttpmarket.OpenOrdersRequest) ([]*mexchttpmarket.GetOrderResponse, error) {
+	return f.orders, nil
+}
+
+func (f *fakeService) WalkWithdrawHistory(_ context.Context, _ mexchttpmarket.WithdrawHistoryRange,
+	fn func([]mexchttpmarket.WithdrawRecord) error) error {
+	return fn(f.withdrawals)
+}
+
+func (f *fakeService) WalkAccountTrades(_ context.Context, req mexchttpmarket.AccountTradesRangeRequest,
+	fn func([]*mexchttpmarket.GetAccountTradeListResponse) error) error {
+	var page []*mexchttpmarket.GetAccountTradeListResponse
+	for _, t := range f.trades {
+		if t.Symbol == req.Symbol {
+			page = append(page, t)
+		}
+	}
+	return fn(page)
+}
+
+func (f *fakeService) GetDepositHistory(_ context.Context,
+	_ mexchttpmarket.DepositHistoryRequest) ([]mexchttpmarket.DepositRecord, error) {
+	return f.deposits, nil
+}
+
+func (f *fakeService) GetTransferHistory(_ context.Context,
+	req mexchttpmarket.OwnTransferHistoryRequest) (*mexchttpmarket.OwnTransferHistoryResponse, error) {
+	rows := f.transfers[req.FromAccountType]
+	return &mexchttpmarket.OwnTransferHistoryResponse{Rows: rows, Total: int32(len(rows))}, nil
+}
+
+func (f *fakeService) WalkInternalTransfers(_ context.Context, _ mexchttpmarket.TransferHistoryRange,
+	fn func([]mexchttpmarket.InternalTransferRecord) error) error {
+	return fn(f.internal)
+}
+
+func (f *fakeService) WalkUniversalTransfers(_ context.Context, r mexchttpmarket.TransferHistoryRange,
+	fn func([]mexchttpmarket.TransferRecord) error) error {
+	var page []mexchttpmarket.TransferRecord
+	for _, t := range f.universal {
+		if t.FromAccountType == r.FromAccountType && t.ToAccountType == r.ToAccountType {
+			page = append(page, t)
+		}
+	}
+	return fn(page)
+}
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "snapshots.jsonl")
+	sink, err := NewJSONLinesSink(path)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	rest := &fakeService{
+		balances: []mexchttpmarket.Balance{
+			{Asset: "USDT", Free: decimal.NewFromInt(1000)},
+			{Asset: "BTC", Free: decimal.NewFromInt(1)},
+		},
+		withdrawals: []mexchttpmarket.WithdrawRecord{
+			{ID: "w1", Coin: "USDT", Status: mexchttpmarket.WithdrawStatusProcessing},
+			{ID: "w2", Coin: "USDT", Status: mexchttpmarket.WithdrawStatusSuccess},
+		},
+	}
+	explainer := NewExplainer(rest, map[string]mexcledger.SymbolAssets{"BTCUSDT": {Base: "BTC", Quote: "USDT"}},
+		ExplainerConfig{Identities: []string{"me@example.com"}, UniversalTransfers: true})
+	recorder := NewRecorder(rest, sink, RecorderConfig{Account: "main", Explainer: explainer})
+
+	first, err := recorder.Record(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, first.Diff)
+	assert.Equal(t, []string{"BTCUSDT"}, first.Snapshot.OpenOrderSymbols)
+	require.Len(t, first.Snapshot.PendingWithdrawals, 1)
+	assert.Equal(t, "w1", first.Snapshot.PendingWithdrawals[0].ID)
+
+	// wait for distinct snapshot times, histories are matched in [from, to)
+	time.Sleep(5 * time.Millisecond)
+	now := time.Now().UnixMilli() - 1
+
+	// bought 0.5 BTC for 500 USDT with 0.001 BTC fee, deposited 100 USDT, withdrew 50 USDT with 1 USDT fee,
+	// moved 20 USDT to futures, received 5 USDT from another user, sent 3 USDT to sub-account; 7 USDT left unexplained
+	rest.balances = []mexchttpmarket.Balance{
+		{Asset: "USDT", Free: decimal.NewFromInt(524)},
+		{Asset: "BTC", Free: decimal.RequireFromString("1.499")},
+	}
+	rest.trades = []*mexchttpmarket.GetAccountTradeListResponse{{
+		Symbol: "BTCUSDT", ID: "t1", Qty: decimal.RequireFromString("0.5"), QuoteQty: decimal.NewFromInt(500),
+		Commission: decimal.RequireFromString("0.001"), CommissionAsset: "BTC", IsBuyer: true, Time: now,
+	}}
+	rest.deposits = []mexchttpmarket.DepositRecord{
+		{TxID: "d1", Coin: "USDT", Amount: decimal.NewFromInt(100), Status: mexchttpmarket.DepositStatusSuccess, InsertTime: now},
+		{TxID: "d2", Coin: "USDT", Amount: decimal.NewFromInt(100), Status: mexchttpmarket.DepositStatusPending, InsertTime: now},
+	}
+	rest.withdrawals = []mexchttpmarket.WithdrawRecord{
+		{ID: "w3", Coin: "USDT", Amount: "50", TransactionFee: "1", Status: mexchttpmarket.WithdrawStatusSuccess, ApplyTime: now},
+		{ID: "w4", Coin: "USDT", Amount: "70", TransactionFee: "1", Status: mexchttpmarket.WithdrawStatusCancel, ApplyTime: now},
+	}
+	rest.transfers = map[mexchttpmarket.AccountType][]mexchttpmarket.OwnTransferRecord{
+		mexchttpmarket.AccountTypeSpot: {{TranId: "tr1", Asset: "USDT", Amount: "20", Status: "SUCCESS", Timestamp: now}},
+	}
+	rest.internal = []mexchttpmarket.InternalTransferRecord{
+		{TranId: "i1", Asset: "USDT", Amount: "5", FromAccount: "friend@example.com", ToAccount: "me@example.com",
+			FromAccountType: "SPOT", ToAccountType: "SPOT", Status: "SUCCESS", Timestamp: now},
+	}
+	rest.universal = []mexchttpmarket.TransferRecord{
+		{TranId: "u1", Asset: "USDT", Amount: "3", ToAccount: "sub1", FromAccountType: mexchttpmarket.AccountTypeSpot,
+			ToAccountType: mexchttpmarket.AccountTypeSpot, Status: "SUCCESS", Timestamp: now},
+		// between sub-accounts
+		{TranId: "u2", Asset: "USDT", Amount: "4", FromAccount: "sub1", ToAccount: "sub2", FromAccountType: mexchttpmarket.AccountTypeSpot,
+			ToAccountType: mexchttpmarket.AccountTypeSpot, Status: "SUCCESS", Timestamp: now},
+		// own transfer, already in own transfer history
+		{TranId: "tr1", Asset: "USDT", Amount: "20", FromAccountType: mexchttpmarket.AccountTypeSpot,
+			ToAccountType: mexchttpmarket.AccountTypeFutures, Status: "SUCCESS", Timestamp: now},
+	}
+
+	second, err := recorder.Record(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, second.Diff)
+	require.Len(t, second.Diff.Changes, 2)
+
+	btc := second.Diff.Changes[0]
+	assert.Equal(t, "BTC", btc.Asset)
+	assert.True(t, btc.Change.Equal(decimal.RequireFromString("0.499")))
+	assert.True(t, btc.Unexplained.IsZero())
+	assert.Len(t, btc.Movements, 2)
+
+	usdt := second.Diff.Changes[1]
+	assert.Equal(t, "USDT", usdt.Asset)
+	assert.True(t, usdt.Change.Equal(decimal.NewFromInt(-476)))
+	assert.True(t, usdt.Explained.Equal(decimal.NewFromInt(-469)), usdt.Explained.String())
+	assert.True(t, usdt.Unexplained.Equal(decimal.NewFromInt(-7)), usdt.Unexplained.String())
+
+	last, err := LastSnapshot(path)
+	require.NoError(t, err)
+	require.NotNil(t, last)
+	assert.True(t, last.TakenAt.Equal(second.Snapshot.TakenAt))
+	assert.Len(t, last.Balances, 2)
+}
+
+func TestRecorder_NoSymbols(t *testing.T) {
+	rest := &fakeService{orders: []*mexchttpmarket.GetOrderResponse{{Symbol: "BTCUSDT"}}}
+	before := time.Now()
+	s, err := NewRecorder(rest, nil, RecorderConfig{}).Capture(context.Background())
+	require.NoError(t, err)
+
+	// no open orders are captured, and snapshot says so
+	assert.NotNil(t, s.OpenOrderSymbols)
+	assert.Empty(t, s.OpenOrderSymbols)
+	assert.Empty(t, s.OpenOrders)
+	assert.False(t, s.TakenAt.Before(before))
+}
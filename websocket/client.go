@@ -77,6 +77,15 @@ func (m *MEXCWebSocket) Unsubscribe(channel string) error {
 	return nil
 }
 
+// Connection returns connection serving subscribed channel
+func (m *MEXCWebSocket) Connection(channel string) (*connection.MEXCWebSocketConnection, bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	conn, ok := m.subscribeMap[channel]
+	return conn, ok
+}
+
 func (m *MEXCWebSocket) getWsConnection(ctx context.Context, params map[string]string,
 	isSubscribe bool) (*connection.MEXCWebSocketConnection, error) {
 	m.mtx.Lock()
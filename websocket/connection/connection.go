@@ -12,6 +12,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -31,6 +32,11 @@ type MEXCWebSocketConnection struct {
 	ctx           context.Context
 	id            string
 	logger        *log.Logger
+	connected     atomic.Bool
+
+	stateMtx       *sync.Mutex
+	stateListeners map[uint64]mexcwstypes.OnStateChange
+	nextListenerID uint64
 }
 
 func NewMEXCWebSocketConnection(url string, errorListener mexcwstypes.OnError) *MEXCWebSocketConnection {
@@ -41,6 +47,9 @@ func NewMEXCWebSocketConnection(url string, errorListener mexcwstypes.OnError) *
 		ErrorListener: errorListener,
 		Subs:          NewSubs(),
 		id:            uuid.NewString(),
+
+		stateMtx:       &sync.Mutex{},
+		stateListeners: make(map[uint64]mexcwstypes.OnStateChange),
 	}
 }
 
@@ -59,10 +68,53 @@ func (m *MEXCWebSocketConnection) Connect(ctx context.Context) error {
 	}
 
 	m.ctx = ctx
+	// state is set before the read loop starts, so an early disconnect isn't overwritten
+	m.setConnected(true)
 	m.run(ctx)
 	return nil
 }
 
+// IsConnected reports whether connection is established and isn't being re-established
+func (m *MEXCWebSocketConnection) IsConnected() bool {
+	return m.connected.Load()
+}
+
+// AddStateListener adds callback called on every connected state change, listener is called with current state immediately.
+// Returned function removes listener.
+func (m *MEXCWebSocketConnection) AddStateListener(listener mexcwstypes.OnStateChange) func() {
+	m.stateMtx.Lock()
+	id := m.nextListenerID
+	m.nextListenerID++
+	m.stateListeners[id] = listener
+	m.stateMtx.Unlock()
+
+	listener(m.IsConnected())
+
+	return func() {
+		m.stateMtx.Lock()
+		defer m.stateMtx.Unlock()
+
+		delete(m.stateListeners, id)
+	}
+}
+
+func (m *MEXCWebSocketConnection) setConnected(connected bool) {
+	if m.connected.Swap(connected) == connected {
+		return
+	}
+
+	m.stateMtx.Lock()
+	listeners := make([]mexcwstypes.OnStateChange, 0, len(m.stateListeners))
+	for _, l := range m.stateListeners {
+		listeners = append(listeners, l)
+	}
+	m.stateMtx.Unlock()
+
+	for _, l := range listeners {
+		l(connected)
+	}
+}
+
 func (m *MEXCWebSocketConnection) Send(message *mexcwstypes.WsReq) error {
 	if m.Conn == nil {
 		return fmt.Errorf("no available connection id: %s", m.id)
@@ -164,6 +216,7 @@ func (m *MEXCWebSocketConnection) handleLoop() {
 
 	msgType, buf, err := m.Conn.ReadMessage()
 	if err != nil {
+		m.setConnected(false)
 		if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
 			m.ErrorListener(true, fmt.Errorf("connection closed: %v", err))
 			return
@@ -233,6 +286,7 @@ func (m *MEXCWebSocketConnection) reconnect() error {
 	}
 
 	m.Conn = newConn
+	m.setConnected(true)
 	// run new connection read loop
 	m.run(m.ctx)
 
@@ -242,6 +296,8 @@ func (m *MEXCWebSocketConnection) reconnect() error {
 			Params: []string{ch},
 		}
 		if err = m.Send(req); err != nil {
+			// connection without subscriptions doesn't deliver updates
+			m.setConnected(false)
 			return fmt.Errorf("resubscription error for channel [%s]: %v", ch, err)
 		}
 	}
@@ -256,6 +312,7 @@ func (m *MEXCWebSocketConnection) getListener(channel string) mexcwstypes.OnRece
 }
 
 func (m *MEXCWebSocketConnection) Disconnect() error {
+	m.setConnected(false)
 	if err := m.Conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
 		time.Now().Add(60*time.Second)); err != nil && !errors.Is(err, websocket.ErrCloseSent) {
 		return err
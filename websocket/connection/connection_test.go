@@ -0,0 +1,23 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMEXCWebSocketConnection_StateListeners(t *testing.T) {
+	conn := NewMEXCWebSocketConnection("", func(bool, error) {})
+
+	var first, second []bool
+	removeFirst := conn.AddStateListener(func(connected bool) { first = append(first, connected) })
+	conn.AddStateListener(func(connected bool) { second = append(second, connected) })
+
+	conn.setConnected(true)
+	conn.setConnected(true)
+	removeFirst()
+	conn.setConnected(false)
+
+	assert.Equal(t, []bool{false, true}, first)
+	assert.Equal(t, []bool{false, true, false}, second)
+}
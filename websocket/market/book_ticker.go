@@ -0,0 +1,45 @@
+package mexcwsmarket
+
+import (
+	"context"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+)
+
+const (
+	BookTickerRequestPattern = "spot@public.aggre.bookTicker.v3.api.pb@%s@%s"
+)
+
+// BookTickerSubscribe subscribes to best bid and ask updates, callback receives ticker and symbol
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#individual-symbol-book-ticker-streams
+func (s *Service) BookTickerSubscribe(ctx context.Context, symbols []string, interval string,
+	callback func(api *dto.PublicAggreBookTickerV3Api, symbol string)) error {
+	lstnr := func(message *dto.PushDataV3ApiWrapper) {
+		switch msg := message.Body.(type) {
+		case *dto.PushDataV3ApiWrapper_PublicAggreBookTicker:
+			callback(msg.PublicAggreBookTicker, message.GetSymbol())
+		default:
+			fmt.Println("BookTicker callback unknown type:", message.Body)
+		}
+	}
+
+	for _, symbol := range symbols {
+		channel := fmt.Sprintf(BookTickerRequestPattern, interval, symbol)
+		if err := s.client.Subscribe(ctx, channel, nil, lstnr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) BookTickerUnsubscribe(symbols []string, interval string) error {
+	for _, symbol := range symbols {
+		channel := fmt.Sprintf(BookTickerRequestPattern, interval, symbol)
+		if err := s.client.Unsubscribe(channel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
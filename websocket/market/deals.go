@@ -0,0 +1,45 @@
+package mexcwsmarket
+
+import (
+	"context"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+)
+
+const (
+	DealsRequestPattern = "spot@public.aggre.deals.v3.api.pb@%s@%s"
+)
+
+// DealsSubscribe subscribes to aggregated trades, callback receives deals and symbol
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#trade-streams
+func (s *Service) DealsSubscribe(ctx context.Context, symbols []string, interval string,
+	callback func(api *dto.PublicAggreDealsV3Api, symbol string)) error {
+	lstnr := func(message *dto.PushDataV3ApiWrapper) {
+		switch msg := message.Body.(type) {
+		case *dto.PushDataV3ApiWrapper_PublicAggreDeals:
+			callback(msg.PublicAggreDeals, message.GetSymbol())
+		default:
+			fmt.Println("Deals callback unknown type:", message.Body)
+		}
+	}
+
+	for _, symbol := range symbols {
+		channel := fmt.Sprintf(DealsRequestPattern, interval, symbol)
+		if err := s.client.Subscribe(ctx, channel, nil, lstnr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) DealsUnsubscribe(symbols []string, interval string) error {
+	for _, symbol := range symbols {
+		channel := fmt.Sprintf(DealsRequestPattern, interval, symbol)
+		if err := s.client.Unsubscribe(channel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package mexcwsmarket
+
+import (
+	"context"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+)
+
+const (
+	MiniTickersRequestPattern = "spot@public.miniTickers.v3.api.pb@%s"
+	MiniTickerRequestPattern  = "spot@public.miniTicker.v3.api.pb@%s@%s"
+	DefaultTimezone           = "UTC+8"
+)
+
+// MiniTickersSubscribe subscribes to mini tickers of all symbols, timezone sets 24h window start, e.g. UTC+8
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#miniticker
+func (s *Service) MiniTickersSubscribe(ctx context.Context, timezone string,
+	callback func(api *dto.PublicMiniTickersV3Api)) error {
+	lstnr := func(message *dto.PushDataV3ApiWrapper) {
+		switch msg := message.Body.(type) {
+		case *dto.PushDataV3ApiWrapper_PublicMiniTickers:
+			callback(msg.PublicMiniTickers)
+		default:
+			fmt.Println("MiniTickers callback unknown type:", message.Body)
+		}
+	}
+
+	return s.client.Subscribe(ctx, fmt.Sprintf(MiniTickersRequestPattern, timezone), nil, lstnr)
+}
+
+func (s *Service) MiniTickersUnsubscribe(timezone string) error {
+	return s.client.Unsubscribe(fmt.Sprintf(MiniTickersRequestPattern, timezone))
+}
+
+// MiniTickerSubscribe subscribes to mini ticker of symbols
+func (s *Service) MiniTickerSubscribe(ctx context.Context, symbols []string, timezone string,
+	callback func(api *dto.PublicMiniTickerV3Api)) error {
+	lstnr := func(message *dto.PushDataV3ApiWrapper) {
+		switch msg := message.Body.(type) {
+		case *dto.PushDataV3ApiWrapper_PublicMiniTicker:
+			callback(msg.PublicMiniTicker)
+		default:
+			fmt.Println("MiniTicker callback unknown type:", message.Body)
+		}
+	}
+
+	for _, symbol := range symbols {
+		channel := fmt.Sprintf(MiniTickerRequestPattern, symbol, timezone)
+		if err := s.client.Subscribe(ctx, channel, nil, lstnr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) MiniTickerUnsubscribe(symbols []string, timezone string) error {
+	for _, symbol := range symbols {
+		if err := s.client.Unsubscribe(fmt.Sprintf(MiniTickerRequestPattern, symbol, timezone)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
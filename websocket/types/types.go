@@ -4,6 +4,7 @@ import "github.com/kattana-io/mexc-golang-sdk/websocket/dto"
 
 type OnReceive func(message *dto.PushDataV3ApiWrapper)
 type OnError func(connClosed bool, err error)
+type OnStateChange func(connected bool)
 
 type WsReq struct {
 	Method string   `json:"method"`
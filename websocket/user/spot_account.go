@@ -0,0 +1,51 @@
+package mexcwsuser
+
+import (
+	"context"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	mexcwstypes "github.com/kattana-io/mexc-golang-sdk/websocket/types"
+)
+
+const (
+	SpotAccountChannel = "spot@private.account.v3.api.pb"
+)
+
+// AccountSubscribe subscribes to user`s spot balance updates, starts listen key keep-alive routine
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#spot-account-update
+func (s *Service) AccountSubscribe(ctx context.Context, callback func(*dto.PrivateAccountV3Api, string), errCallback mexcwstypes.OnError) error {
+	listenKey, err := s.httpStream.CreateListenKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func(ctx context.Context, listenKey string) {
+		kErr := s.httpStream.RunKeyKeepAlive(ctx, listenKey)
+		if kErr != nil {
+			errCallback(true, kErr)
+		}
+	}(ctx, listenKey)
+
+	lstnr := func(message *dto.PushDataV3ApiWrapper) {
+		var pair string
+		if message.Symbol != nil {
+			pair = *message.Symbol
+		}
+
+		switch msg := message.Body.(type) {
+		case *dto.PushDataV3ApiWrapper_PrivateAccount:
+			callback(msg.PrivateAccount, pair)
+		default:
+			fmt.Println("Account callback unknown type:", message.Body)
+		}
+	}
+
+	params := map[string]string{
+		"listenKey": listenKey,
+	}
+	return s.wsClient.Subscribe(ctx, SpotAccountChannel, params, lstnr)
+}
+
+func (s *Service) AccountUnsubscribe() error {
+	return s.wsClient.Unsubscribe(SpotAccountChannel)
+}
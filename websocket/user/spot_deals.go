@@ -0,0 +1,51 @@
+package mexcwsuser
+
+import (
+	"context"
+	"fmt"
+	"github.com/kattana-io/mexc-golang-sdk/websocket/dto"
+	mexcwstypes "github.com/kattana-io/mexc-golang-sdk/websocket/types"
+)
+
+const (
+	SpotDealsChannel = "spot@private.deals.v3.api.pb"
+)
+
+// DealsSubscribe subscribes to user`s spot deals, starts listen key keep-alive routine
+// https://mexcdevelop.github.io/apidocs/spot_v3_en/#spot-account-deals
+func (s *Service) DealsSubscribe(ctx context.Context, callback func(*dto.PrivateDealsV3Api, string), errCallback mexcwstypes.OnError) error {
+	listenKey, err := s.httpStream.CreateListenKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func(ctx context.Context, listenKey string) {
+		kErr := s.httpStream.RunKeyKeepAlive(ctx, listenKey)
+		if kErr != nil {
+			errCallback(true, kErr)
+		}
+	}(ctx, listenKey)
+
+	lstnr := func(message *dto.PushDataV3ApiWrapper) {
+		var pair string
+		if message.Symbol != nil {
+			pair = *message.Symbol
+		}
+
+		switch msg := message.Body.(type) {
+		case *dto.PushDataV3ApiWrapper_PrivateDeals:
+			callback(msg.PrivateDeals, pair)
+		default:
+			fmt.Println("Deals callback unknown type:", message.Body)
+		}
+	}
+
+	params := map[string]string{
+		"listenKey": listenKey,
+	}
+	return s.wsClient.Subscribe(ctx, SpotDealsChannel, params, lstnr)
+}
+
+func (s *Service) DealsUnsubscribe() error {
+	return s.wsClient.Unsubscribe(SpotDealsChannel)
+}
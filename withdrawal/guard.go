@@ -0,0 +1,317 @@
+package mexcwithdrawal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rejection reasons reported in RejectionError
+const (
+	RejectNotAllowlisted   = "not_allowlisted"
+	RejectApprovalRequired = "approval_required"
+	RejectApprovalDenied   = "approval_denied"
+	RejectSelfApproval     = "self_approval"
+	RejectInvalidRequest   = "invalid_request"
+	RejectAuditUnavailable = "audit_unavailable"
+)
+
+// Audit outcomes
+const (
+	OutcomeAttempt   = "attempt"
+	OutcomeRejected  = "rejected"
+	OutcomeSubmitted = "submitted"
+	OutcomeFailed    = "failed"
+)
+
+// RejectionError is returned when guard refuses withdrawal, the request is not sent
+type RejectionError struct {
+	Reason  string
+	Coin    string
+	Network string
+	Address string
+	Detail  string
+	Err     error // optional cause, e.g. *ValidationError
+}
+
+func (e *RejectionError) Error() string {
+	return fmt.Sprintf("withdrawal of %s to %s on %s rejected (%s): %s", e.Coin, e.Address, e.Network, e.Reason, e.Detail)
+}
+
+func (e *RejectionError) Unwrap() error {
+	return e.Err
+}
+
+// WithdrawService is a part of market service which sends withdrawals
+type WithdrawService interface {
+	Withdraw(ctx context.Context, req mexchttpmarket.WithdrawRequest) (*mexchttpmarket.WithdrawResponse, error)
+}
+
+type AllowedAddress struct {
+	Coin    string
+	Network string
+	Address string
+	Memo    string // optional, if set withdrawal memo must match
+}
+
+// Allowlist of withdrawal addresses per coin and network
+type Allowlist struct {
+	mtx       *sync.RWMutex
+	addresses map[string]AllowedAddress
+}
+
+func NewAllowlist(addresses ...AllowedAddress) *Allowlist {
+	a := &Allowlist{
+		mtx:       new(sync.RWMutex),
+		addresses: make(map[string]AllowedAddress),
+	}
+	for _, address := range addresses {
+		a.Add(address)
+	}
+	return a
+}
+
+func (a *Allowlist) Add(address AllowedAddress) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.addresses[allowlistKey(address.Coin, address.Network, address.Address)] = address
+}
+
+func (a *Allowlist) Remove(coin, network, address string) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	delete(a.addresses, allowlistKey(coin, network, address))
+}
+
+func (a *Allowlist) Allowed(coin, network, address, memo string) bool {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	allowed, ok := a.addresses[allowlistKey(coin, network, address)]
+	return ok && (allowed.Memo == "" || allowed.Memo == memo)
+}
+
+// allowlistKey keeps address case, base58 and bech32 addresses are case-sensitive
+func allowlistKey(coin, network, address string) string {
+	return strings.ToUpper(coin) + "|" + strings.ToUpper(network) + "|" + address
+}
+
+type ApprovalRequest struct {
+	Requester string
+	Request   mexchttpmarket.WithdrawRequest
+}
+
+// Approval is granted by the second person, Token is recorded to audit
+type Approval struct {
+	Approver string
+	Token    string
+}
+
+// Approver asks the second person to approve withdrawal, nil approval or error denies it
+type Approver func(ctx context.Context, req ApprovalRequest) (*Approval, error)
+
+// AuditEntry is written before the request is sent and once outcome is known
+type AuditEntry struct {
+	Time       time.Time                      `json:"time"`
+	Outcome    string                         `json:"outcome"`
+	Requester  string                         `json:"requester"`
+	Request    mexchttpmarket.WithdrawRequest `json:"request"`
+	Approver   string                         `json:"approver,omitempty"`
+	Token      string                         `json:"token,omitempty"`
+	WithdrawID string                         `json:"withdrawId,omitempty"`
+	Error      string                         `json:"error,omitempty"`
+}
+
+type AuditSink interface {
+	Record(ctx context.Context, entry AuditEntry) error
+}
+
+// JSONLinesAudit writes audit entries as JSON lines, e.g. to append-only file
+type JSONLinesAudit struct {
+	mtx *sync.Mutex
+	w   io.Writer
+}
+
+func NewJSONLinesAudit(w io.Writer) *JSONLinesAudit {
+	return &JSONLinesAudit{
+		mtx: new(sync.Mutex),
+		w:   w,
+	}
+}
+
+func (a *JSONLinesAudit) Record(_ context.Context, entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	_, err = a.w.Write(append(line, '\n'))
+	return err
+}
+
+type GuardConfig struct {
+	Allowlist          *Allowlist                 // required, request network must be set unless Validator resolves it, resolved network matches by name or symbol
+	Audit              AuditSink                  // required
+	Approver           Approver                   // required if any threshold is set
+	ApprovalThresholds map[string]decimal.Decimal // keyed by upper-cased coin, amounts at or above threshold need approval
+	Validator          *Validator                 // optional pre-flight validation
+	Requester          string                     // default requester, see WithRequester
+	OnAuditError       func(error)                // optional, reports failed outcome records
+}
+
+// Guard refuses withdrawals to addresses outside of allowlist and asks for approval of large ones.
+// Attempt is recorded before the request is sent, so withdrawal is never sent unaudited.
+type Guard struct {
+	next WithdrawService
+	cfg  GuardConfig
+}
+
+func NewGuard(next WithdrawService, cfg GuardConfig) (*Guard, error) {
+	if cfg.Allowlist == nil {
+		return nil, errors.New("allowlist is required")
+	}
+	if cfg.Audit == nil {
+		return nil, errors.New("audit sink is required")
+	}
+	if len(cfg.ApprovalThresholds) > 0 && cfg.Approver == nil {
+		return nil, errors.New("approver is required with approval thresholds")
+	}
+
+	return &Guard{
+		next: next,
+		cfg:  cfg,
+	}, nil
+}
+
+type requesterKey struct{}
+
+// WithRequester sets identity of the person requesting withdrawal
+func WithRequester(ctx context.Context, requester string) context.Context {
+	return context.WithValue(ctx, requesterKey{}, requester)
+}
+
+// Withdraw matches mexchttpmarket.Service.Withdraw
+func (g *Guard) Withdraw(ctx context.Context, req mexchttpmarket.WithdrawRequest) (*mexchttpmarket.WithdrawResponse, error) {
+	entry := AuditEntry{
+		Requester: g.cfg.Requester,
+		Request:   req,
+	}
+	if requester, ok := ctx.Value(requesterKey{}).(string); ok {
+		entry.Requester = requester
+	}
+
+	req, approval, err := g.check(ctx, entry.Requester, req)
+	entry.Request = req
+	if err != nil {
+		entry.Outcome, entry.Error = OutcomeRejected, err.Error()
+		g.record(ctx, entry)
+		return nil, err
+	}
+	if approval != nil {
+		entry.Approver, entry.Token = approval.Approver, approval.Token
+	}
+
+	entry.Outcome = OutcomeAttempt
+	entry.Time = time.Now()
+	if err := g.cfg.Audit.Record(ctx, entry); err != nil {
+		return nil, g.reject(RejectAuditUnavailable, req, err.Error())
+	}
+
+	resp, err := g.next.Withdraw(ctx, req)
+	if err != nil {
+		entry.Outcome, entry.Error = OutcomeFailed, err.Error()
+		g.record(ctx, entry)
+		return nil, err
+	}
+
+	entry.Outcome, entry.WithdrawID = OutcomeSubmitted, resp.Id
+	g.record(ctx, entry)
+
+	return resp, nil
+}
+
+// check returns request to send, it has network and contract resolved by validator if validator is set
+func (g *Guard) check(ctx context.Context, requester string,
+	req mexchttpmarket.WithdrawRequest) (mexchttpmarket.WithdrawRequest, *Approval, error) {
+	var networks []string
+	if req.Network != nil {
+		networks = []string{*req.Network}
+	}
+	if g.cfg.Validator != nil {
+		preflight, err := g.cfg.Validator.Validate(req)
+		if err != nil {
+			rejection := g.reject(RejectInvalidRequest, req, err.Error())
+			rejection.Err = err
+			return req, nil, rejection
+		}
+		// aliases of resolved network, e.g. TRC20 and TRX
+		networks = []string{preflight.Network.Network, preflight.Network.NetworkSymbol}
+		req = preflight.Request
+	}
+
+	memo := ""
+	if req.Memo != nil {
+		memo = *req.Memo
+	}
+	if !g.allowed(req, networks, memo) {
+		return req, nil, g.reject(RejectNotAllowlisted, req, "address is not on allowlist")
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		return req, nil, g.reject(RejectInvalidRequest, req, fmt.Sprintf("invalid amount %q", req.Amount))
+	}
+
+	threshold, ok := g.cfg.ApprovalThresholds[strings.ToUpper(req.Coin)]
+	if !ok || amount.LessThan(threshold) {
+		return req, nil, nil
+	}
+
+	approval, err := g.cfg.Approver(ctx, ApprovalRequest{Requester: requester, Request: req})
+	switch {
+	case err != nil:
+		return req, nil, g.reject(RejectApprovalDenied, req, err.Error())
+	case approval == nil || approval.Token == "":
+		return req, nil, g.reject(RejectApprovalRequired, req, fmt.Sprintf("amount %s needs approval", amount))
+	case approval.Approver == "" || approval.Approver == requester:
+		return req, nil, g.reject(RejectSelfApproval, req, "approver must differ from requester")
+	}
+
+	return req, approval, nil
+}
+
+func (g *Guard) allowed(req mexchttpmarket.WithdrawRequest, networks []string, memo string) bool {
+	for _, network := range networks {
+		if network != "" && g.cfg.Allowlist.Allowed(req.Coin, network, req.Address, memo) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Guard) reject(reason string, req mexchttpmarket.WithdrawRequest, detail string) *RejectionError {
+	network := ""
+	if req.Network != nil {
+		network = *req.Network
+	}
+	return &RejectionError{Reason: reason, Coin: req.Coin, Network: network, Address: req.Address, Detail: detail}
+}
+
+func (g *Guard) record(ctx context.Context, entry AuditEntry) {
+	entry.Time = time.Now()
+	if err := g.cfg.Audit.Record(ctx, entry); err != nil && g.cfg.OnAuditError != nil {
+		g.cfg.OnAuditError(fmt.Errorf("audit %s withdrawal: %w", entry.Outcome, err))
+	}
+}
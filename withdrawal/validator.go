@@ -0,0 +1,249 @@
+package mexcwithdrawal
+
+import (
+	"context"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DefaultAddressFormats are address patterns of common networks keyed by upper-cased network name
+var DefaultAddressFormats = map[string]*regexp.Regexp{
+	"ERC20":      regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`),
+	"ETH":        regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`),
+	"BEP20":      regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`),
+	"BEP20(BSC)": regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`),
+	"BSC":        regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`),
+	"ARBITRUM":   regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`),
+	"OPTIMISM":   regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`),
+	"POLYGON":    regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`),
+	"MATIC":      regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`),
+	"TRC20":      regexp.MustCompile(`^T[1-9A-HJ-NP-Za-km-z]{33}$`),
+	"TRX":        regexp.MustCompile(`^T[1-9A-HJ-NP-Za-km-z]{33}$`),
+	"BTC":        regexp.MustCompile(`^(bc1[02-9ac-hj-np-z]{11,71}|[13][1-9A-HJ-NP-Za-km-z]{25,34})$`),
+	"SOL":        regexp.MustCompile(`^[1-9A-HJ-NP-Za-km-z]{32,44}$`),
+	"XRP":        regexp.MustCompile(`^r[1-9A-HJ-NP-Za-km-z]{24,34}$`),
+}
+
+// DefaultMemoNetworks override memo requirement of networks known to credit exchange deposits by memo or tag,
+// other networks require memo when their config has shared deposit address (SameAddress)
+var DefaultMemoNetworks = map[string]bool{
+	"XRP":   true,
+	"XLM":   true,
+	"EOS":   true,
+	"ATOM":  true,
+	"TON":   true,
+	"HBAR":  true,
+	"KAVA":  true,
+	"IOST":  true,
+	"OSMO":  true,
+	"WAXP":  true,
+	"STEEM": true,
+	"XEM":   true,
+}
+
+// CurrencyService is a part of market service which returns currency network config
+type CurrencyService interface {
+	CurrencyInformation(ctx context.Context) ([]*mexchttpmarket.CoinInfoResponse, error)
+}
+
+// ValidationError is returned when withdrawal would be rejected or lost
+type ValidationError struct {
+	Coin   string
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s withdrawal %s: %s", e.Coin, e.Field, e.Reason)
+}
+
+type ValidatorConfig struct {
+	AddressFormats map[string]*regexp.Regexp // DefaultAddressFormats if nil, networks without format aren't checked
+	MemoNetworks   map[string]bool           // memo requirement overrides keyed by upper-cased network, DefaultMemoNetworks if nil
+}
+
+// Preflight is a validated withdrawal, NetAmount is received amount after withdraw fee.
+// Request is the validated request with resolved network and network contract, it should be sent instead of the original one.
+type Preflight struct {
+	Request   mexchttpmarket.WithdrawRequest
+	Network   mexchttpmarket.CoinWithdrawInfo
+	Amount    decimal.Decimal
+	Fee       decimal.Decimal
+	NetAmount decimal.Decimal
+}
+
+// Validator checks withdrawals against currency network config
+type Validator struct {
+	addressFormats map[string]*regexp.Regexp
+	memoNetworks   map[string]bool
+
+	mtx   *sync.RWMutex
+	coins map[string][]mexchttpmarket.CoinWithdrawInfo
+}
+
+func NewValidator(coins []*mexchttpmarket.CoinInfoResponse, cfg ValidatorConfig) *Validator {
+	v := &Validator{
+		addressFormats: cfg.AddressFormats,
+		memoNetworks:   cfg.MemoNetworks,
+		mtx:            new(sync.RWMutex),
+	}
+	if v.addressFormats == nil {
+		v.addressFormats = DefaultAddressFormats
+	}
+	if v.memoNetworks == nil {
+		v.memoNetworks = DefaultMemoNetworks
+	}
+	v.Update(coins)
+
+	return v
+}
+
+// LoadValidator creates validator from current currency information
+func LoadValidator(ctx context.Context, rest CurrencyService, cfg ValidatorConfig) (*Validator, error) {
+	coins, err := rest.CurrencyInformation(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("currency information: %w", err)
+	}
+	return NewValidator(coins, cfg), nil
+}
+
+// Update replaces currency network config, e.g. after periodic CurrencyInformation call
+func (v *Validator) Update(coins []*mexchttpmarket.CoinInfoResponse) {
+	byCoin := make(map[string][]mexchttpmarket.CoinWithdrawInfo, len(coins))
+	for _, c := range coins {
+		byCoin[strings.ToUpper(c.Coin)] = c.NetworkList
+	}
+
+	v.mtx.Lock()
+	v.coins = byCoin
+	v.mtx.Unlock()
+}
+
+// Validate checks withdraw request, network is resolved when coin has a single withdrawable network
+func (v *Validator) Validate(req mexchttpmarket.WithdrawRequest) (*Preflight, error) {
+	network, err := v.network(req)
+	if err != nil {
+		return nil, err
+	}
+
+	invalid := func(field, format string, args ...any) error {
+		return &ValidationError{Coin: req.Coin, Field: field, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	if !network.WithdrawEnable {
+		return nil, invalid("network", "withdraw is disabled on %s", network.Network)
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || !amount.IsPositive() {
+		return nil, invalid("amount", "%q is not a positive number", req.Amount)
+	}
+	if amount.LessThan(network.WithdrawMin) {
+		return nil, invalid("amount", "%s is below minimum %s", amount, network.WithdrawMin)
+	}
+	if network.WithdrawMax.IsPositive() && amount.GreaterThan(network.WithdrawMax) {
+		return nil, invalid("amount", "%s is above maximum %s", amount, network.WithdrawMax)
+	}
+	if multiple, err := decimal.NewFromString(network.WithdrawIntegerMultiple); err == nil && multiple.IsPositive() {
+		if !amount.Mod(multiple).IsZero() {
+			return nil, invalid("amount", "%s is not a multiple of %s", amount, multiple)
+		}
+	}
+
+	netAmount := amount.Sub(network.WithdrawFee)
+	if !netAmount.IsPositive() {
+		return nil, invalid("amount", "%s doesn't cover withdraw fee %s", amount, network.WithdrawFee)
+	}
+
+	if req.Address == "" {
+		return nil, invalid("address", "address is required")
+	}
+	if format, ok := v.format(network); ok && !format.MatchString(req.Address) {
+		return nil, invalid("address", "%q is not a valid %s address", req.Address, network.Network)
+	}
+	if v.requiresMemo(network) && (req.Memo == nil || *req.Memo == "") {
+		return nil, invalid("memo", "memo is required on %s", network.Network)
+	}
+
+	resolved := req
+	networkName := network.NetworkSymbol
+	if networkName == "" {
+		networkName = network.Network
+	}
+	resolved.Network = &networkName
+
+	switch {
+	case req.ContractAddress == nil && network.Contract != "":
+		contract := network.Contract
+		resolved.ContractAddress = &contract
+	case req.ContractAddress == nil:
+	case network.Contract == "":
+		return nil, invalid("contract", "%s is given, but %s has no contract", *req.ContractAddress, network.Network)
+	case !strings.EqualFold(*req.ContractAddress, network.Contract):
+		return nil, invalid("contract", "%s doesn't match %s contract %s", *req.ContractAddress, network.Network, network.Contract)
+	}
+
+	return &Preflight{
+		Request:   resolved,
+		Network:   network,
+		Amount:    amount,
+		Fee:       network.WithdrawFee,
+		NetAmount: netAmount,
+	}, nil
+}
+
+func (v *Validator) network(req mexchttpmarket.WithdrawRequest) (mexchttpmarket.CoinWithdrawInfo, error) {
+	v.mtx.RLock()
+	networks, ok := v.coins[strings.ToUpper(req.Coin)]
+	v.mtx.RUnlock()
+
+	if !ok {
+		return mexchttpmarket.CoinWithdrawInfo{}, &ValidationError{Coin: req.Coin, Field: "coin", Reason: "unknown coin"}
+	}
+
+	if req.Network == nil || *req.Network == "" {
+		var enabled []mexchttpmarket.CoinWithdrawInfo
+		for _, n := range networks {
+			if n.WithdrawEnable {
+				enabled = append(enabled, n)
+			}
+		}
+		if len(enabled) == 1 {
+			return enabled[0], nil
+		}
+		return mexchttpmarket.CoinWithdrawInfo{}, &ValidationError{Coin: req.Coin, Field: "network",
+			Reason: fmt.Sprintf("network is required, coin has %d withdrawable networks", len(enabled))}
+	}
+
+	for _, n := range networks {
+		if strings.EqualFold(n.Network, *req.Network) || strings.EqualFold(n.NetworkSymbol, *req.Network) {
+			return n, nil
+		}
+	}
+
+	return mexchttpmarket.CoinWithdrawInfo{}, &ValidationError{Coin: req.Coin, Field: "network",
+		Reason: fmt.Sprintf("coin is not supported on %s", *req.Network)}
+}
+
+func (v *Validator) format(network mexchttpmarket.CoinWithdrawInfo) (*regexp.Regexp, bool) {
+	for _, name := range []string{network.NetworkSymbol, network.Network} {
+		if format, ok := v.addressFormats[strings.ToUpper(name)]; ok {
+			return format, true
+		}
+	}
+	return nil, false
+}
+
+// requiresMemo takes override of network name first, then shared deposit address flag of network config
+func (v *Validator) requiresMemo(network mexchttpmarket.CoinWithdrawInfo) bool {
+	for _, name := range []string{network.NetworkSymbol, network.Network} {
+		if required, ok := v.memoNetworks[strings.ToUpper(name)]; ok {
+			return required
+		}
+	}
+	return network.SameAddress
+}
@@ -0,0 +1,216 @@
+package mexcwithdrawal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultPollInterval = 30 * time.Second
+	historyWindowMargin = time.Minute // withdraw apply time may be slightly before Watch call
+)
+
+// HistoryService is a part of market service which returns withdraw history
+type HistoryService interface {
+	WalkWithdrawHistory(ctx context.Context, r mexchttpmarket.WithdrawHistoryRange, fn func([]mexchttpmarket.WithdrawRecord) error) error
+}
+
+// Transition of withdrawal status, From is zero for the first observed status
+type Transition struct {
+	ID     string
+	From   mexchttpmarket.WithdrawStatus
+	To     mexchttpmarket.WithdrawStatus
+	Record mexchttpmarket.WithdrawRecord
+}
+
+// Withdrawal is a watched withdrawal, it's done when status is failed or cancelled,
+// or when it's successful and transaction ID is known
+type Withdrawal struct {
+	ID        string
+	Coin      string
+	AppliedAt time.Time
+
+	mtx    *sync.Mutex
+	record *mexchttpmarket.WithdrawRecord
+	done   chan struct{}
+}
+
+func (w *Withdrawal) Done() <-chan struct{} {
+	return w.done
+}
+
+// Record returns last observed record, ok is false until withdrawal is found in history
+func (w *Withdrawal) Record() (mexchttpmarket.WithdrawRecord, bool) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.record == nil {
+		return mexchttpmarket.WithdrawRecord{}, false
+	}
+	return *w.record, true
+}
+
+// Wait returns final record, error is returned if withdrawal failed or was cancelled
+func (w *Withdrawal) Wait(ctx context.Context) (mexchttpmarket.WithdrawRecord, error) {
+	select {
+	case <-ctx.Done():
+		return mexchttpmarket.WithdrawRecord{}, ctx.Err()
+	case <-w.done:
+	}
+
+	record, _ := w.Record()
+	if record.Status != mexchttpmarket.WithdrawStatusSuccess {
+		return record, fmt.Errorf("withdrawal %s finished with status %d", w.ID, record.Status)
+	}
+	return record, nil
+}
+
+// Watcher polls withdraw history of watched withdrawals and reports status transitions
+type Watcher struct {
+	rest         HistoryService
+	interval     time.Duration
+	onTransition func(Transition)
+
+	mtx         *sync.Mutex
+	withdrawals map[string]*Withdrawal
+}
+
+// NewWatcher onTransition is optional, DefaultPollInterval is used if interval is zero
+func NewWatcher(rest HistoryService, interval time.Duration, onTransition func(Transition)) *Watcher {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	return &Watcher{
+		rest:         rest,
+		interval:     interval,
+		onTransition: onTransition,
+		mtx:          new(sync.Mutex),
+		withdrawals:  make(map[string]*Withdrawal),
+	}
+}
+
+// Watch starts tracking withdrawal returned by Withdraw, appliedAt bounds the history window
+func (w *Watcher) Watch(id, coin string, appliedAt time.Time) *Withdrawal {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if existing, ok := w.withdrawals[id]; ok {
+		return existing
+	}
+
+	withdrawal := &Withdrawal{
+		ID:        id,
+		Coin:      coin,
+		AppliedAt: appliedAt,
+		mtx:       new(sync.Mutex),
+		done:      make(chan struct{}),
+	}
+	w.withdrawals[id] = withdrawal
+
+	return withdrawal
+}
+
+// Pending returns withdrawals which are not done yet
+func (w *Watcher) Pending() []*Withdrawal {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	res := make([]*Withdrawal, 0, len(w.withdrawals))
+	for _, withdrawal := range w.withdrawals {
+		res = append(res, withdrawal)
+	}
+	return res
+}
+
+// Run polls history every interval until ctx is done
+func (w *Watcher) Run(ctx context.Context, errCallback func(error)) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.Poll(ctx); err != nil && errCallback != nil {
+				errCallback(err)
+			}
+		}
+	}
+}
+
+// Poll walks history once per coin of pending withdrawals, from the earliest apply time till now
+func (w *Watcher) Poll(ctx context.Context) error {
+	now := time.Now()
+	since := make(map[string]time.Time)
+	for _, withdrawal := range w.Pending() {
+		if t, ok := since[withdrawal.Coin]; !ok || withdrawal.AppliedAt.Before(t) {
+			since[withdrawal.Coin] = withdrawal.AppliedAt
+		}
+	}
+
+	var errs []error
+	for coin, start := range since {
+		coin := coin
+		err := w.rest.WalkWithdrawHistory(ctx, mexchttpmarket.WithdrawHistoryRange{
+			Coin:  &coin,
+			Start: start.Add(-historyWindowMargin),
+			End:   now.Add(historyWindowMargin),
+		}, func(records []mexchttpmarket.WithdrawRecord) error {
+			for i := range records {
+				w.update(records[i])
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("withdraw history %s: %w", coin, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (w *Watcher) update(record mexchttpmarket.WithdrawRecord) {
+	w.mtx.Lock()
+	withdrawal, ok := w.withdrawals[record.ID]
+	w.mtx.Unlock()
+	if !ok {
+		return
+	}
+
+	withdrawal.mtx.Lock()
+	var from mexchttpmarket.WithdrawStatus
+	if withdrawal.record != nil {
+		from = withdrawal.record.Status
+	}
+	withdrawal.record = &record
+	withdrawal.mtx.Unlock()
+
+	if from != record.Status && w.onTransition != nil {
+		w.onTransition(Transition{ID: record.ID, From: from, To: record.Status, Record: record})
+	}
+
+	if !isDone(record) {
+		return
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if w.withdrawals[record.ID] == withdrawal {
+		delete(w.withdrawals, record.ID)
+		close(withdrawal.done)
+	}
+}
+
+func isDone(record mexchttpmarket.WithdrawRecord) bool {
+	if record.Status != mexchttpmarket.WithdrawStatusSuccess {
+		return record.Status.IsFinal()
+	}
+	// successful withdrawal is done once transaction is known
+	return record.TxID != nil && *record.TxID != ""
+}
@@ -0,0 +1,52 @@
+package mexcwithdrawal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHistory struct {
+	records []mexchttpmarket.WithdrawRecord
+}
+
+func (f *fakeHistory) WalkWithdrawHistory(_ context.Context, _ mexchttpmarket.WithdrawHistoryRange,
+	fn func([]mexchttpmarket.WithdrawRecord) error) error {
+	return fn(f.records)
+}
+
+func TestWatcher(t *testing.T) {
+	history := &fakeHistory{}
+	var transitions []Transition
+	w := NewWatcher(history, time.Second, func(tr Transition) { transitions = append(transitions, tr) })
+	ctx := context.Background()
+
+	withdrawal := w.Watch("1", "USDT", time.Now())
+
+	history.records = []mexchttpmarket.WithdrawRecord{{ID: "1", Status: mexchttpmarket.WithdrawStatusAuditing}}
+	require.NoError(t, w.Poll(ctx))
+	history.records[0].Status = mexchttpmarket.WithdrawStatusSuccess
+	require.NoError(t, w.Poll(ctx))
+
+	select {
+	case <-withdrawal.Done():
+		t.Fatal("success without tx id is not final")
+	default:
+	}
+
+	txID := "0xabc"
+	history.records[0].TxID = &txID
+	require.NoError(t, w.Poll(ctx))
+
+	record, err := withdrawal.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, txID, *record.TxID)
+	require.Len(t, transitions, 2)
+	assert.Equal(t, mexchttpmarket.WithdrawStatusAuditing, transitions[1].From)
+	assert.Equal(t, mexchttpmarket.WithdrawStatusSuccess, transitions[1].To)
+	assert.Empty(t, w.Pending())
+}
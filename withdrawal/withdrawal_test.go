@@ -0,0 +1,189 @@
+package mexcwithdrawal
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	mexchttpmarket "github.com/kattana-io/mexc-golang-sdk/http/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidator(t *testing.T) {
+	v := NewValidator([]*mexchttpmarket.CoinInfoResponse{{
+		Coin: "USDT",
+		NetworkList: []mexchttpmarket.CoinWithdrawInfo{
+			{Network: "TRC20", NetworkSymbol: "TRX", WithdrawEnable: true, WithdrawMin: decimal.NewFromInt(10),
+				WithdrawMax: decimal.NewFromInt(1000), WithdrawFee: decimal.NewFromInt(1), WithdrawIntegerMultiple: "0.01"},
+			{Network: "ERC20", NetworkSymbol: "ETH", WithdrawEnable: false},
+		},
+	}}, ValidatorConfig{})
+
+	req := mexchttpmarket.WithdrawRequest{Coin: "USDT", Address: "TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf", Amount: "25.5"}
+	preflight, err := v.Validate(req)
+	require.NoError(t, err)
+	assert.Equal(t, "TRC20", preflight.Network.Network)
+	assert.Equal(t, "24.5", preflight.NetAmount.String())
+
+	var vErr *ValidationError
+	for field, mutate := range map[string]func(r *mexchttpmarket.WithdrawRequest){
+		"amount":  func(r *mexchttpmarket.WithdrawRequest) { r.Amount = "5" },
+		"address": func(r *mexchttpmarket.WithdrawRequest) { r.Address = "0x123" },
+		"network": func(r *mexchttpmarket.WithdrawRequest) { n := "ETH"; r.Network = &n },
+	} {
+		r := req
+		mutate(&r)
+		_, err := v.Validate(r)
+		require.ErrorAs(t, err, &vErr, field)
+		assert.Equal(t, field, vErr.Field)
+	}
+}
+
+func TestValidator_NetworkConfig(t *testing.T) {
+	v := NewValidator([]*mexchttpmarket.CoinInfoResponse{
+		{Coin: "USDT", NetworkList: []mexchttpmarket.CoinWithdrawInfo{
+			{Network: "TRC20", NetworkSymbol: "TRX", Contract: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", WithdrawEnable: true,
+				WithdrawMax: decimal.NewFromInt(1000)},
+		}},
+		{Coin: "NEW", NetworkList: []mexchttpmarket.CoinWithdrawInfo{
+			{Network: "NEWCHAIN", NetworkSymbol: "NEW", SameAddress: true, WithdrawEnable: true,
+				WithdrawMax: decimal.NewFromInt(1000)},
+		}},
+	}, ValidatorConfig{MemoNetworks: map[string]bool{"TRX": true}})
+
+	var vErr *ValidationError
+
+	// override wins over config without shared address
+	_, err := v.Validate(mexchttpmarket.WithdrawRequest{Coin: "USDT", Address: "TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf", Amount: "10"})
+	require.ErrorAs(t, err, &vErr)
+	assert.Equal(t, "memo", vErr.Field)
+
+	// shared deposit address requires memo without override
+	_, err = v.Validate(mexchttpmarket.WithdrawRequest{Coin: "NEW", Address: "addr", Amount: "10"})
+	require.ErrorAs(t, err, &vErr)
+	assert.Equal(t, "memo", vErr.Field)
+
+	memo := "123"
+	preflight, err := v.Validate(mexchttpmarket.WithdrawRequest{Coin: "NEW", Address: "addr", Amount: "10", Memo: &memo})
+	require.NoError(t, err)
+	require.NotNil(t, preflight.Request.Network)
+	assert.Equal(t, "NEW", *preflight.Request.Network)
+	assert.Nil(t, preflight.Request.ContractAddress)
+
+	// resolved network and its contract are written back
+	req := mexchttpmarket.WithdrawRequest{Coin: "USDT", Address: "TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf", Amount: "10", Memo: &memo}
+	preflight, err = v.Validate(req)
+	require.NoError(t, err)
+	assert.Nil(t, req.Network)
+	require.NotNil(t, preflight.Request.Network)
+	assert.Equal(t, "TRX", *preflight.Request.Network)
+	require.NotNil(t, preflight.Request.ContractAddress)
+	assert.Equal(t, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", *preflight.Request.ContractAddress)
+
+	contract := "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	req.ContractAddress = &contract
+	_, err = v.Validate(req)
+	require.ErrorAs(t, err, &vErr)
+	assert.Equal(t, "contract", vErr.Field)
+
+	_, err = v.Validate(mexchttpmarket.WithdrawRequest{Coin: "NEW", Address: "addr", Amount: "10", Memo: &memo, ContractAddress: &contract})
+	require.ErrorAs(t, err, &vErr)
+	assert.Equal(t, "contract", vErr.Field)
+}
+
+type fakeWithdrawService struct {
+	sent int
+	last mexchttpmarket.WithdrawRequest
+}
+
+func (f *fakeWithdrawService) Withdraw(_ context.Context,
+	req mexchttpmarket.WithdrawRequest) (*mexchttpmarket.WithdrawResponse, error) {
+	f.sent++
+	f.last = req
+	return &mexchttpmarket.WithdrawResponse{Id: "w1"}, nil
+}
+
+func TestGuard(t *testing.T) {
+	rest := &fakeWithdrawService{}
+	var audit bytes.Buffer
+	approver := "bob"
+	g, err := NewGuard(rest, GuardConfig{
+		Allowlist:          NewAllowlist(AllowedAddress{Coin: "USDT", Network: "TRC20", Address: "Taddr"}),
+		Audit:              NewJSONLinesAudit(&audit),
+		ApprovalThresholds: map[string]decimal.Decimal{"USDT": decimal.NewFromInt(1000)},
+		Approver: func(_ context.Context, _ ApprovalRequest) (*Approval, error) {
+			return &Approval{Approver: approver, Token: "token"}, nil
+		},
+	})
+	require.NoError(t, err)
+
+	network := "TRC20"
+	ctx := WithRequester(context.Background(), "alice")
+	req := mexchttpmarket.WithdrawRequest{Coin: "USDT", Network: &network, Address: "Tother", Amount: "10"}
+
+	var rejection *RejectionError
+	_, err = g.Withdraw(ctx, req)
+	require.ErrorAs(t, err, &rejection)
+	assert.Equal(t, RejectNotAllowlisted, rejection.Reason)
+
+	req.Address = "Taddr"
+	resp, err := g.Withdraw(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "w1", resp.Id)
+
+	req.Amount = "5000"
+	approver = "alice"
+	_, err = g.Withdraw(ctx, req)
+	require.ErrorAs(t, err, &rejection)
+	assert.Equal(t, RejectSelfApproval, rejection.Reason)
+
+	approver = "bob"
+	_, err = g.Withdraw(ctx, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, rest.sent)
+	// rejected, attempt + submitted, rejected, attempt + submitted
+	assert.Equal(t, 6, strings.Count(audit.String(), "\n"))
+	assert.Contains(t, audit.String(), `"approver":"bob"`)
+}
+
+func TestGuard_Validator(t *testing.T) {
+	rest := &fakeWithdrawService{}
+	validator := NewValidator([]*mexchttpmarket.CoinInfoResponse{{
+		Coin: "USDT",
+		NetworkList: []mexchttpmarket.CoinWithdrawInfo{
+			{Network: "TRC20", NetworkSymbol: "TRX", WithdrawEnable: true, WithdrawMax: decimal.NewFromInt(1000)},
+		},
+	}}, ValidatorConfig{})
+	address := "TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf"
+	g, err := NewGuard(rest, GuardConfig{
+		Allowlist: NewAllowlist(AllowedAddress{Coin: "USDT", Network: "TRC20", Address: address}),
+		Audit:     NewJSONLinesAudit(&bytes.Buffer{}),
+		Validator: validator,
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// alias of allowlisted network
+	network := "TRX"
+	_, err = g.Withdraw(ctx, mexchttpmarket.WithdrawRequest{Coin: "USDT", Network: &network, Address: address, Amount: "10"})
+	require.NoError(t, err)
+
+	// network resolved by validator is sent
+	_, err = g.Withdraw(ctx, mexchttpmarket.WithdrawRequest{Coin: "USDT", Address: address, Amount: "10"})
+	require.NoError(t, err)
+	require.NotNil(t, rest.last.Network)
+	assert.Equal(t, "TRX", *rest.last.Network)
+
+	var rejection *RejectionError
+	var vErr *ValidationError
+	_, err = g.Withdraw(ctx, mexchttpmarket.WithdrawRequest{Coin: "USDT", Address: address, Amount: "5000"})
+	require.ErrorAs(t, err, &rejection)
+	assert.Equal(t, RejectInvalidRequest, rejection.Reason)
+	require.ErrorAs(t, err, &vErr)
+	assert.Equal(t, "amount", vErr.Field)
+	assert.Equal(t, 2, rest.sent)
+}